@@ -17,30 +17,36 @@ import (
 
 const redfishRootPath = "/redfish/v1"
 
-const (
-	UNKNOWN = iota
-	DELL
-	HPE
-	LENOVO
-	INSPUR
-	H3C
-	HUAWEI
-)
-
 type Client struct {
-	deviceName  string
-	host        string
-	username    string
-	password    string
-	httpClient  *http.Client
-	vendor      int
-	version     int
-	systemPath  string
-	thermalPath string
-	powerPath   string
-	storagePath string
-	memoryPath  string
-	networkPath string
+	deviceName       string
+	host             string
+	username         string
+	password         string
+	httpClient       *http.Client
+	profile          VendorProfile
+	systemPath       string
+	thermalPath      string
+	powerPath        string
+	storagePath      string
+	memoryPath       string
+	networkPath      string
+	processorsPath   string
+	pcieDevices      []Odata
+	pcieFunctions    []Odata
+	pcieDevicesPath  string
+	pcieSlotsPath    string
+	managersPath     string
+	logServicesPath  string
+	eventServicePath string
+	telemetryPath    string
+
+	// nameMu guards deviceName, client.mu is held for the whole endpoint discovery
+	nameMu sync.Mutex
+
+	mu           sync.Mutex
+	discoveredAt time.Time
+	selPath      string
+	selFound     bool
 }
 
 func newHttpClient() *http.Client {
@@ -52,16 +58,32 @@ func newHttpClient() *http.Client {
 	}
 }
 
-func NewClient(authInfo tools.Data, deviceName string) (*Client, error) {
-	client := &Client{
+func newClient(authInfo tools.Data, deviceName string) *Client {
+	return &Client{
 		deviceName: deviceName,
 		host:       authInfo.Dat.Host,
 		username:   authInfo.Dat.Account,
 		password:   authInfo.Dat.Password,
 		httpClient: newHttpClient(),
 	}
+}
 
-	err := client.findAllEndpoints()
+func (client *Client) getDeviceName() string {
+	client.nameMu.Lock()
+	defer client.nameMu.Unlock()
+	return client.deviceName
+}
+
+func (client *Client) setDeviceName(deviceName string) {
+	client.nameMu.Lock()
+	client.deviceName = deviceName
+	client.nameMu.Unlock()
+}
+
+func NewClient(authInfo tools.Data, deviceName string) (*Client, error) {
+	client := newClient(authInfo, deviceName)
+
+	err := client.ensureEndpoints()
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +91,21 @@ func NewClient(authInfo tools.Data, deviceName string) (*Client, error) {
 	return client, nil
 }
 
+// ensureEndpoints discovers the endpoints once, a failed discovery is retried on the next call
+func (client *Client) ensureEndpoints() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if !client.discoveredAt.IsZero() {
+		return nil
+	}
+	err := client.findAllEndpoints()
+	if err != nil {
+		return err
+	}
+	client.discoveredAt = time.Now()
+	return nil
+}
+
 func (client *Client) findAllEndpoints() error {
 	var root V1Response
 	var group GroupResponse
@@ -110,39 +147,21 @@ func (client *Client) findAllEndpoints() error {
 	client.storagePath = system.Storage.OdataId
 	client.memoryPath = system.Memory.OdataId
 	client.networkPath = system.NetworkInterfaces.OdataId
+	client.processorsPath = system.Processors.OdataId
+	client.pcieDevices = system.PCIeDevices
+	client.pcieFunctions = system.PCIeFunctions
+	client.pcieDevicesPath = chassis.PCIeDevices.OdataId
+	client.pcieSlotsPath = chassis.PCIeSlots.OdataId
 	client.thermalPath = chassis.Thermal.OdataId
 	client.powerPath = chassis.Power.OdataId
+	client.managersPath = root.Managers.OdataId
+	client.eventServicePath = root.EventService.OdataId
+	client.telemetryPath = root.TelemetryService.OdataId
+	client.logServicesPath = system.LogServices.OdataId
 
 	// Vendor
-	m := strings.ToLower(system.Manufacturer)
-	log.Printf("vender is %q", system.Manufacturer)
-	if strings.Contains(m, "dell") {
-		client.vendor = DELL
-	} else if strings.Contains(m, "hpe") {
-		client.vendor = HPE
-	} else if strings.Contains(m, "lenovo") {
-		client.vendor = LENOVO
-	} else if strings.Contains(m, "inspur") {
-		client.vendor = INSPUR
-	} else if strings.Contains(m, "h3c") {
-		client.vendor = H3C
-	} else if strings.Contains(m, "huawei") {
-		client.vendor = HUAWEI
-	}
-
-	// Fix for Inspur bug
-	if client.vendor == INSPUR {
-		client.storagePath = strings.ReplaceAll(client.storagePath, "Storages", "Storage")
-	}
-
-	// Fix for iLO 4 machines
-	if client.vendor == HPE {
-		if strings.Contains(root.Name, "HP RESTful") {
-			client.memoryPath = "/redfish/v1/Systems/1/Memory/"
-			client.storagePath = "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/"
-			client.version = 4
-		}
-	}
+	client.profile = detectVendor(&root, &system)
+	client.profile.Endpoints(client, &root)
 
 	return nil
 }
@@ -154,6 +173,7 @@ func (client *Client) RefreshSensors(mc *Collector, ch chan string) error {
 	if err != nil {
 		return err
 	}
+	client.profile.NormalizeThermal(&resp)
 
 	for n, t := range resp.Temperatures {
 		if t.Status.State != StateEnabled && t.Status.State != "ENABLE" {
@@ -165,7 +185,18 @@ func (client *Client) RefreshSensors(mc *Collector, ch chan string) error {
 		}
 
 		id := t.GetId(n)
-		ch <- mc.NewSensorsTemperature(t.ReadingCelsius, id, t.Name, "celsius")
+		thresholds := t.thresholds()
+		ch <- mc.NewSensorsTemperature(t.ReadingCelsius, id, t.Name, t.PhysicalContext, "celsius")
+		for _, th := range thresholds {
+			ch <- mc.NewSensorsTemperatureThreshold(th.value, id, t.Name, t.PhysicalContext, th.name)
+		}
+		ch <- mc.NewSensorThresholdState("temperature", id, t.Name, t.PhysicalContext, thresholdState(t.ReadingCelsius, thresholds))
+		if min, ok := sensorNumber(t.MinReadingRangeTemp); ok {
+			ch <- mc.NewSensorsTemperatureReadingRange(min, id, t.Name, t.PhysicalContext, "min")
+		}
+		if max, ok := sensorNumber(t.MaxReadingRangeTemp); ok {
+			ch <- mc.NewSensorsTemperatureReadingRange(max, id, t.Name, t.PhysicalContext, "max")
+		}
 	}
 
 	for n, f := range resp.Fans {
@@ -184,10 +215,33 @@ func (client *Client) RefreshSensors(mc *Collector, ch chan string) error {
 		}
 
 		id := f.GetId(n)
-		ch <- mc.NewSensorsFanHealth(id, name, f.Status.Health)
-		ch <- mc.NewSensorsFanSpeed(f.GetReading(), "", name, strings.ToLower(units))
+		units = strings.ToLower(units)
+		thresholds := f.thresholds()
+		if !fanThresholdsComparable(units, thresholds) {
+			thresholds = nil
+		}
+		ch <- mc.NewSensorsFanHealth(id, name, f.PhysicalContext, f.Status.Health)
+		for _, th := range thresholds {
+			ch <- mc.NewSensorsFanThreshold(th.value, id, name, f.PhysicalContext, units, th.name)
+		}
+		if min, ok := sensorNumber(f.MinReadingRange); ok {
+			ch <- mc.NewSensorsFanReadingRange(min, id, name, f.PhysicalContext, units, "min")
+		}
+		if max, ok := sensorNumber(f.MaxReadingRange); ok {
+			ch <- mc.NewSensorsFanReadingRange(max, id, name, f.PhysicalContext, units, "max")
+		}
+		if reading, ok := f.GetReading(); ok {
+			ch <- mc.NewSensorsFanSpeed(reading, id, name, f.PhysicalContext, units)
+			ch <- mc.NewSensorThresholdState("fan", id, name, f.PhysicalContext, thresholdState(reading, thresholds))
+		}
 	}
 
+	var embedded [][]Redundancy
+	for n, f := range resp.Fans {
+		embedded = append(embedded, redundancyPointers("/Fans/"+strconv.Itoa(n), f.Redundancy))
+	}
+	client.refreshRedundancy(mc, ch, "thermal", redundancySets(resp.Redundancy, embedded...))
+
 	return nil
 }
 
@@ -198,7 +252,7 @@ func (client *Client) RefreshSystem(mc *Collector, ch chan string) error {
 		return err
 	}
 	ch <- mc.NewSystemPowerOn(resp.PowerState)
-	ch <- mc.NewSystemHealth(resp.Status.Health, client.deviceName)
+	ch <- mc.NewSystemHealth(resp.Status.Health, client.getDeviceName())
 	ch <- mc.NewSystemIndicatorLED(resp.IndicatorLED)
 	if resp.MemorySummary != nil {
 		ch <- mc.NewSystemMemorySize(resp.MemorySummary.TotalSystemMemoryGiB * 1073741824)
@@ -226,6 +280,7 @@ func (client *Client) RefreshNetwork(mc *Collector, ch chan string) error {
 		if err != nil {
 			return err
 		}
+		client.profile.NormalizeNetworkInterface(&ni)
 
 		if ni.Status.State != StateEnabled {
 			continue
@@ -239,19 +294,16 @@ func (client *Client) RefreshNetwork(mc *Collector, ch chan string) error {
 			return err
 		}
 
-		port := NetworkPort{}
 		for _, c := range ports.Members {
 			wg.Add(1)
 			go func(c Odata) {
-				err = client.redfishGet(c.OdataId, &port)
+				port := NetworkPort{}
+				err := client.redfishGet(c.OdataId, &port)
 				if err != nil {
 					log.Println(err)
 				}
-				if client.vendor == HUAWEI && port.Status.State == "Enabled" {
-					ch <- mc.NewNetworkPortHealth(ni.Id, port.Id, "OK")
-				} else {
-					ch <- mc.NewNetworkPortHealth(ni.Id, port.Id, port.Status.Health)
-				}
+				client.profile.NormalizeNetworkPort(&port)
+				ch <- mc.NewNetworkPortHealth(ni.Id, port.Id, client.profile.Health(port.Status))
 				ch <- mc.NewNetworkPortSpeed(ni.Id, port.Id, port.GetSpeed())
 				ch <- mc.NewNetworkPortLinkUp(ni.Id, port.Id, port.LinkStatus)
 				wg.Done()
@@ -274,20 +326,60 @@ func (client *Client) RefreshPower(mc *Collector, ch chan string) error {
 		if psu.Status.State != StateEnabled && psu.Status.State != "ENABLE" {
 			continue
 		}
+		client.profile.NormalizePowerSupply(&psu)
 
-		id := strconv.Itoa(i)
+		id := psu.GetId(i)
+		ch <- mc.NewPowerSupplyInfo(id, &psu)
 		ch <- mc.NewPowerSupplyHealth(psu.Status.Health, id)
 		ch <- mc.NewPowerSupplyInputWatts(psu.PowerInputWatts, id)
 		ch <- mc.NewPowerSupplyInputVoltage(psu.LineInputVoltage, id)
 		ch <- mc.NewPowerSupplyOutputWatts(psu.GetOutputPower(), id)
 		ch <- mc.NewPowerSupplyCapacityWatts(psu.PowerCapacityWatts, id)
 		ch <- mc.NewPowerSupplyEfficiencyPercent(psu.EfficiencyPercent, id)
+
+		for n, r := range psu.InputRanges {
+			// null and 0 both mean the bmc doesn't know the bound, dc ranges have no frequency
+			index := strconv.Itoa(n)
+			if r.MinimumVoltage > 0 {
+				ch <- mc.NewPowerSupplyInputRangeVoltage(r.MinimumVoltage, id, index, r.InputType, "min")
+			}
+			if r.MaximumVoltage > 0 {
+				ch <- mc.NewPowerSupplyInputRangeVoltage(r.MaximumVoltage, id, index, r.InputType, "max")
+			}
+			if r.MinimumFrequencyHz > 0 {
+				ch <- mc.NewPowerSupplyInputRangeFrequency(r.MinimumFrequencyHz, id, index, r.InputType, "min")
+			}
+			if r.MaximumFrequencyHz > 0 {
+				ch <- mc.NewPowerSupplyInputRangeFrequency(r.MaximumFrequencyHz, id, index, r.InputType, "max")
+			}
+			if r.OutputWattage > 0 {
+				ch <- mc.NewPowerSupplyInputRangeOutputWatts(r.OutputWattage, id, index, r.InputType)
+			}
+		}
 	}
 
 	for i, pc := range resp.PowerControl {
 		id := strconv.Itoa(i)
 		ch <- mc.NewPowerControlConsumedWatts(pc.PowerConsumedWatts, id, pc.Name)
 		ch <- mc.NewPowerControlCapacityWatts(pc.PowerCapacityWatts, id, pc.Name)
+		if allocated, ok := sensorNumber(pc.PowerAllocatedWatts); ok {
+			ch <- mc.NewPowerControlAllocatedWatts(allocated, id, pc.Name)
+		}
+		if available, ok := sensorNumber(pc.PowerAvailableWatts); ok {
+			ch <- mc.NewPowerControlAvailableWatts(available, id, pc.Name)
+		}
+		if requested, ok := sensorNumber(pc.PowerRequestedWatts); ok {
+			ch <- mc.NewPowerControlRequestedWatts(requested, id, pc.Name)
+		}
+
+		// a limit of zero or null means power capping is switched off
+		capping := pc.PowerLimit != nil && pc.PowerLimit.LimitInWatts > 0
+		ch <- mc.NewPowerControlCappingActive(capping, id, pc.Name)
+		if capping {
+			pl := pc.PowerLimit
+			ch <- mc.NewPowerControlLimitWatts(pl.LimitInWatts, id, pc.Name, pl.LimitException)
+			ch <- mc.NewPowerControlLimitCorrection(pl.CorrectionInMs, id, pc.Name)
+		}
 
 		if pc.PowerMetrics == nil {
 			continue
@@ -300,246 +392,233 @@ func (client *Client) RefreshPower(mc *Collector, ch chan string) error {
 		ch <- mc.NewPowerControlInterval(pm.IntervalInMinutes, id, pc.Name)
 	}
 
+	client.refreshVoltages(mc, ch, resp.Voltages)
+
+	var embedded [][]Redundancy
+	for n, psu := range resp.PowerSupplies {
+		embedded = append(embedded, redundancyPointers("/PowerSupplies/"+strconv.Itoa(n), psu.Redundancy))
+	}
+	client.refreshRedundancy(mc, ch, "power", redundancySets(resp.Redundancy, embedded...))
+
 	return nil
 }
 
 func (client *Client) RefreshIdracSel(mc *Collector, ch chan string) error {
-	if client.vendor == HUAWEI {
-		var resp LogRes
-		err := client.redfishGetHuaweiLog(&resp)
-		if err != nil {
-			return err
-		}
+	entries, err := client.profile.SelEntries(client)
+	if err != nil {
+		return err
+	}
 
-		for _, e := range resp.Error.ExtendedInfo[0].Oem.Huawei.SelLogEntries {
-			if e.AlertTime == "" {
-				continue
-			}
-			t, _ := time.Parse("2006-01-02 15:04:05", e.AlertTime)
-			switch {
-			case e.Level == "0":
-				e.Level = "normal"
-			case e.Level == "1":
-				e.Level = "warning"
-			case e.Level == "2":
-				e.Level = "error"
-			case e.Level == "3":
-				e.Level = "critical"
-			default:
-				e.Level = "unknown"
-			}
-			ch <- mc.NewSelEntry(e.EventID, e.EventDesc, "Unknown", e.Level, t)
-		}
-	} else if client.vendor == INSPUR {
-		var resp InspurSelResponse
-		err := client.redfishGet(redfishRootPath+"/Managers/1/LogServices/Log/Entries", &resp)
-		if err != nil {
-			return err
-		}
-		for _, e := range resp.Members {
-			st := e.SensorType
-			if st == "" {
-				st = "Unknown"
-			}
-			// translate origin time
-			finalTime, _ := time.Parse("06-01-02 15:04:05", e.Created)
-			ch <- mc.NewSelEntry(e.ID, e.Message, st, e.Severity, finalTime)
+	tracker := selTrackerOf(client.host)
+	fresh, initial := tracker.observe(entries, mc.config.Sel.Latest)
+	tracker.emit(mc, ch)
+	// the log present at startup was most likely forwarded by the previous run,
+	// only the entries logged shortly before are forwarded again
+	if initial {
+		fresh = selSince(fresh, mc.config.Sel.Lookback)
+	}
+	forwardLogs(mc.config.Outputs, client.selRecords(fresh))
+
+	if mc.config.Sel.Legacy {
+		for _, e := range entries {
+			ch <- mc.NewSelEntry(e.Id, e.Message, e.Component, e.Severity, e.Created)
 		}
-	} else {
-		var resp IdracSelResponse
-		err := client.redfishGet(redfishRootPath+"/Managers/iDRAC.Embedded.1/Logs/Sel", &resp)
+	}
+
+	return nil
+}
+
+// standardStorage reads the storage collection and the drive links of every controller in it
+func (client *Client) standardStorage(path string) ([]StorageController, []Odata, error) {
+	group := GroupResponse{}
+	err := client.redfishGet(path, &group)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var drives []Odata
+	ctlrs := make([]StorageController, 0, len(group.Members))
+	for _, c := range group.Members {
+		ctlr := StorageController{}
+		err = client.redfishGet(c.OdataId, &ctlr)
 		if err != nil {
-			return err
-		}
-		log.Printf("log is %s", resp.Members)
-		for _, e := range resp.Members {
-			st := string(e.SensorType)
-			if st == "" {
-				st = "Unknown"
-			}
-			ch <- mc.NewSelEntry(e.Id, e.Message, st, e.Severity, e.Created)
+			log.Printf("磁盘信息采集错误-%s", err.Error())
+			continue
 		}
+		ctlr.OdataId = c.OdataId
+		ctlrs = append(ctlrs, ctlr)
+		drives = append(drives, ctlr.Drives...)
 	}
+	return ctlrs, drives, nil
+}
 
-	return nil
+func (client *Client) getDrive(path string) (Drive, error) {
+	drive := Drive{}
+	err := client.redfishGet(path, &drive)
+	if err != nil {
+		return drive, err
+	}
+	client.profile.NormalizeDrive(&drive)
+	drive.Model = strings.Trim(drive.Model, " ")
+	drive.SerialNumber = strings.Trim(drive.SerialNumber, " ")
+	return drive, nil
 }
 
-type inspurDriveResponse struct {
-	Members []Odata `json:"Members"`
+func driveId(id string) string {
+	if strings.Contains(id, "HDDPlaneDisk") {
+		id = strings.ReplaceAll(id, "HDDPlaneDisk", "磁盘槽")
+	} else if strings.Contains(id, "mainboardSDCard") {
+		id = strings.ReplaceAll(id, "mainboardSDCard", "主板SD卡")
+	} else if strings.Contains(id, ":Enclosure.Internal") {
+		id = strings.Split(id, ":")[0]
+		id = strings.ReplaceAll(id, "Disk.Bay.", "磁盘槽")
+	} else if strings.Contains(id, "HDDPlaneDisk00000") {
+		id = strings.ReplaceAll(id, "HDDPlaneDisk00000", "磁盘槽")
+	}
+	return id
 }
 
 func (client *Client) RefreshStorage(mc *Collector, ch chan string) error {
 	var wg sync.WaitGroup
-	group := GroupResponse{}
-	sPath := client.storagePath
-	if client.vendor == INSPUR {
-		sPath = "/redfish/v1/Systems/1/Storages"
-	}
-	err := client.redfishGet(sPath, &group)
+	ctlrs, drives, err := client.profile.Storage(client)
 	if err != nil {
 		return err
 	}
-	if client.vendor == INSPUR {
-		grp := inspurDriveResponse{}
-		ctlr := StorageController{}
-		err = client.redfishGet("/redfish/v1/Chassis/1/Drives", &grp)
-		if err != nil {
-			return err
-		}
+	client.refreshStorageControllers(mc, ch, ctlrs)
 
-		ctlr.Drives = grp.Members
-		drive := Drive{}
-		limitChan := make(chan int, 8)
+	for _, d := range drives {
+		wg.Add(1)
+		go func(d Odata) {
+			defer wg.Done()
+			drive, err := client.getDrive(d.OdataId)
+			if err != nil {
+				log.Printf("磁盘信息采集错误-%s", err.Error())
+				return
+			}
 
-		for _, d := range ctlr.Drives {
-			wg.Add(1)
-			go func(d Odata) {
-				limitChan <- 1
-				err = client.redfishGet(d.OdataId, &drive)
-				if err != nil {
-					log.Printf("磁盘信息采集错误-%s", err.Error())
-				}
-				// iLO 4
-				if (client.vendor == HPE) && (client.version == 4) {
-					drive.CapacityBytes = 1024 * 1024 * drive.CapacityMiB
-					drive.Protocol = drive.InterfaceType
-					drive.PredictedLifeLeft = 100 - drive.SSDEnduranceUtilizationPercentage
-				}
+			id := driveId(drive.Id)
+			ch <- mc.NewDriveInfo(id, drive.Name, drive.Manufacturer, drive.Model, drive.SerialNumber, drive.MediaType, drive.Protocol, drive.GetSlot())
+			ch <- mc.NewDriveHealth(id, client.profile.Health(drive.Status))
+			ch <- mc.NewDriveCapacity(id, drive.CapacityBytes)
+			ch <- mc.NewDriveLifeLeft(id, drive.PredictedLifeLeft)
+		}(d)
+	}
+	wg.Wait()
+	return nil
+}
 
-				var id = drive.Id
-				if strings.Contains(id, "HDDPlaneDisk") {
-					id = strings.ReplaceAll(id, "HDDPlaneDisk", "磁盘槽")
-				} else if strings.Contains(id, "mainboardSDCard") {
-					id = strings.ReplaceAll(id, "mainboardSDCard", "主板SD卡")
-				} else if strings.Contains(id, ":Enclosure.Internal") {
-					id = strings.Split(id, ":")[0]
-					id = strings.ReplaceAll(id, "Disk.Bay.", "磁盘槽")
-				} else if strings.Contains(id, "HDDPlaneDisk00000") {
-					id = strings.ReplaceAll(id, "HDDPlaneDisk00000", "磁盘槽")
-				}
+func (client *Client) getMemory(path string) (Memory, error) {
+	m := Memory{}
+	err := client.redfishGet(path, &m)
+	if err != nil {
+		return m, err
+	}
+	client.profile.NormalizeMemory(&m)
+	return m, nil
+}
 
-				ch <- mc.NewDriveInfo(id, drive.Name, drive.Manufacturer, strings.Trim(drive.Model, " "), strings.Trim(drive.SerialNumber, " "), drive.MediaType, drive.Protocol, drive.GetSlot())
-				ch <- mc.NewDriveHealth(id, drive.Status.Health)
-				ch <- mc.NewDriveCapacity(id, drive.CapacityBytes)
-				ch <- mc.NewDriveLifeLeft(id, drive.PredictedLifeLeft)
-				<-limitChan
-				wg.Done()
-			}(d)
-		}
-		wg.Wait()
-	} else {
-		for _, c := range group.Members {
-			wg.Add(1)
-			go func(d Odata) {
-				ctlr := StorageController{}
-				err = client.redfishGet(c.OdataId, &ctlr)
-				if err != nil {
-					log.Printf("磁盘信息采集错误-%s", err.Error())
-				}
-				// iLO 4
-				if (client.vendor == HPE) && (client.version == 4) {
-					grp := GroupResponse{}
-					err = client.redfishGet(c.OdataId+"DiskDrives/", &grp)
-					if err != nil {
-						log.Printf("磁盘信息采集错误-%s", err.Error())
-					}
-					ctlr.Drives = grp.Members
-				}
-				var wg2 sync.WaitGroup
-				for _, d := range ctlr.Drives {
-					wg2.Add(1)
-					go func(d Odata) {
-						drive := Drive{}
-						err = client.redfishGet(d.OdataId, &drive)
-						if err != nil {
-							log.Printf("磁盘信息采集错误-%s", err.Error())
-						}
-
-						// iLO 4
-						if (client.vendor == HPE) && (client.version == 4) {
-							drive.CapacityBytes = 1024 * 1024 * drive.CapacityMiB
-							drive.Protocol = drive.InterfaceType
-							drive.PredictedLifeLeft = 100 - drive.SSDEnduranceUtilizationPercentage
-						}
-
-						var id = drive.Id
-						if strings.Contains(id, "HDDPlaneDisk") {
-							id = strings.ReplaceAll(id, "HDDPlaneDisk", "磁盘槽")
-						} else if strings.Contains(id, "mainboardSDCard") {
-							id = strings.ReplaceAll(id, "mainboardSDCard", "主板SD卡")
-						} else if strings.Contains(id, ":Enclosure.Internal") {
-							id = strings.Split(id, ":")[0]
-							id = strings.ReplaceAll(id, "Disk.Bay.", "磁盘槽")
-						} else if strings.Contains(id, "HDDPlaneDisk00000") {
-							id = strings.ReplaceAll(id, "HDDPlaneDisk00000", "磁盘槽")
-						}
-
-						ch <- mc.NewDriveInfo(id, drive.Name, drive.Manufacturer, drive.Model, drive.SerialNumber, drive.MediaType, drive.Protocol, drive.GetSlot())
-						ch <- mc.NewDriveHealth(id, drive.Status.Health)
-						ch <- mc.NewDriveCapacity(id, drive.CapacityBytes)
-						ch <- mc.NewDriveLifeLeft(id, drive.PredictedLifeLeft)
-						wg2.Done()
-					}(d)
-				}
-				wg2.Wait()
-			}(c)
-		}
-		wg.Wait()
+func memoryId(id string) string {
+	if strings.Contains(id, "mainboardDIMM") {
+		id = strings.ReplaceAll(id, "mainboardDIMM", "内存槽")
+		id = strings.ReplaceAll(id, ".Socket.", "-")
+	} else if strings.Contains(id, "DIMM.Socket") {
+		id = strings.ReplaceAll(id, "DIMM.Socket", "内存槽")
+	} else if strings.Contains(id, "DIMM") {
+		id = strings.ReplaceAll(id, "DIMM", "内存槽")
 	}
-	return nil
+	return id
 }
 
 func (client *Client) RefreshMemory(mc *Collector, ch chan string) error {
 	var group GroupResponse
-	var m Memory
 
 	err := client.redfishGet(client.memoryPath, &group)
 	if err != nil {
 		return err
 	}
 	var wg sync.WaitGroup
-	limitChan := make(chan int, 7)
 	for _, c := range group.Members {
-		if m.Status.State == StateAbsent {
-			continue
-		}
 		wg.Add(1)
-		go func() {
-			limitChan <- 1
-			err = client.redfishGet(c.OdataId, &m)
+		go func(c Odata) {
+			defer wg.Done()
+			m, err := client.getMemory(c.OdataId)
 			if err != nil {
-				log.Printf(err.Error())
-			}
-			// iLO 4
-			if (client.vendor == HPE) && (client.version == 4) {
-				m.Manufacturer = strings.TrimSpace(m.Manufacturer)
-				m.RankCount = m.Rank
-				m.MemoryDeviceType = m.DIMMType
-				m.Status.Health = m.DIMMStatus
-				m.CapacityMiB = m.SizeMB
+				log.Println(err.Error())
+				return
 			}
-			var id = m.Id
-			if strings.Contains(id, "mainboardDIMM") {
-				id = strings.ReplaceAll(id, "mainboardDIMM", "内存槽")
-				id = strings.ReplaceAll(id, ".Socket.", "-")
-			} else if strings.Contains(id, "DIMM.Socket") {
-				id = strings.ReplaceAll(id, "DIMM.Socket", "内存槽")
-			} else if strings.Contains(id, "DIMM") {
-				id = strings.ReplaceAll(id, "DIMM", "内存槽")
+			if m.Status.State == StateAbsent {
+				return
 			}
 
+			id := memoryId(m.Id)
 			ch <- mc.NewMemoryModuleInfo(id, m.Name, m.Manufacturer, m.MemoryDeviceType, m.SerialNumber, m.ErrorCorrection, m.RankCount)
-			ch <- mc.NewMemoryModuleHealth(id, m.Status.Health)
+			ch <- mc.NewMemoryModuleHealth(id, client.profile.Health(m.Status))
 			ch <- mc.NewMemoryModuleCapacity(id, m.CapacityMiB*1048576)
 			ch <- mc.NewMemoryModuleSpeed(id, m.OperatingSpeedMhz)
-			<-limitChan
-			wg.Done()
-		}()
+		}(c)
 	}
 	wg.Wait()
 	return nil
 }
 
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// do sends the request through the circuit breaker and the scheduler of the host, retrying
+// transient failures of idempotent requests, the scheduler slot is held until the response body is closed
+func (client *Client) do(req *http.Request) (*http.Response, error) {
+	b := breakerOf(client.host)
+	err := client.allow(b)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		release := requestScheduler.acquire(client.host)
+		resp, err := client.httpClient.Do(req)
+		retry, code := retryable(resp, err)
+		var delay time.Duration
+		if retry {
+			delay = retryDelay(attempt, resp)
+		}
+		// the retries of one request stop once the next one would start after retryMaxTotal
+		if !retry || attempt+1 >= retryAttempts || !canRetry(req) || (req.Body != nil && req.GetBody == nil) ||
+			time.Since(start)+delay > retryMaxTotal {
+			b.record(err == nil && resp.StatusCode < 500)
+			if err != nil {
+				release()
+				return nil, err
+			}
+			resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+		release()
+		countRetry(client.host, code)
+		log.Printf("Retrying url %q in %s after %s", req.URL, delay, code)
+		time.Sleep(delay)
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
 func (client *Client) redfishGet(path string, res interface{}) error {
 	if !strings.HasPrefix(path, redfishRootPath) {
 		return fmt.Errorf("invalid url for redfish request")
@@ -554,7 +633,7 @@ func (client *Client) redfishGet(path string, res interface{}) error {
 	req.Header.Add("Accept", "application/json")
 	req.SetBasicAuth(client.username, client.password)
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(req)
 
 	if resp != nil {
 		defer resp.Body.Close()
@@ -588,78 +667,65 @@ func (client *Client) redfishGet(path string, res interface{}) error {
 	return nil
 }
 
-type LogRes struct {
-	Error struct {
-		Code         string         `json:"code"`
-		Message      string         `json:"message"`
-		ExtendedInfo []ExtendedInfo `json:"@Message.ExtendedInfo"`
-	} `json:"error"`
-}
-
-type ExtendedInfo struct {
-	Oem Oem `json:"Oem"`
-}
+// redfishPost creates a resource and returns the location of it
+func (client *Client) redfishPost(path string, body interface{}) (string, error) {
+	if !strings.HasPrefix(path, redfishRootPath) {
+		return "", fmt.Errorf("invalid url for redfish request")
+	}
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
 
-type Oem struct {
-	Huawei Huawei `json:"Huawei"`
-}
+	url := "https://" + client.host + path
+	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+	req.SetBasicAuth(client.username, client.password)
 
-type Huawei struct {
-	SelLogEntries []SelLogEntry `json:"SelLogEntries"`
-}
+	resp, err := client.do(req)
+	if err != nil {
+		log.Printf("Failed to post url %q: %v", url, err)
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		log.Printf("Post to url %q returned unexpected status code: %d (%s)", url, resp.StatusCode, resp.Status)
+		return "", fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
 
-type SelLogEntry struct {
-	Level        string          `json:"level,omitempty"`
-	EventID      string          `json:"eventid,omitempty"`
-	SubjectType  string          `json:"subjecttype,omitempty"`
-	EventDesc    string          `json:"eventdesc,omitempty"`
-	TrigMode     string          `json:"trigmode,omitempty"`
-	AlertTime    string          `json:"alerttime,omitempty"`
-	Status       string          `json:"status,omitempty"`
-	EventCode    string          `json:"eventcode,omitempty"`
-	OldEventCode string          `json:"oldeventcode,omitempty"`
-	EventSubject string          `json:"eventsubject,omitempty"`
-	EventSugg    string          `json:"eventsugg,omitempty"`
-	Number       json.RawMessage `json:"number,omitempty"`
+	location := resp.Header.Get("Location")
+	if i := strings.Index(location, redfishRootPath); i > 0 {
+		location = location[i:]
+	}
+	return location, nil
 }
 
-func (client *Client) redfishGetHuaweiLog(res *LogRes) error {
-	url := "https://" + client.host + "/redfish/v1/Systems/1/LogServices/Log1/Actions/Oem/Huawei/LogService.QuerySelLogEntries"
-	data := map[string]interface{}{
-		"StartEntryId": 1,
-		"EntriesCount": 50,
-	}
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
+func (client *Client) redfishDelete(path string) error {
+	if !strings.HasPrefix(path, redfishRootPath) {
+		return fmt.Errorf("invalid url for redfish request")
 	}
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+
+	url := "https://" + client.host + path
+	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
 		return err
 	}
-
 	req.Header.Add("Accept", "application/json")
 	req.SetBasicAuth(client.username, client.password)
 
-	resp, err := client.httpClient.Do(req)
-
-	if resp != nil {
-		defer resp.Body.Close()
-	}
+	resp, err := client.do(req)
 	if err != nil {
-		log.Printf("Failed to query url %q: %v", url, err)
+		log.Printf("Failed to delete url %q: %v", url, err)
 		return err
 	}
-
-	if resp.StatusCode != 200 {
-		log.Printf("Query to url %q returned unexpected status code: %d (%s)", url, resp.StatusCode, resp.Status)
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		log.Printf("Delete of url %q returned unexpected status code: %d (%s)", url, resp.StatusCode, resp.Status)
 		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
 	}
-	re, _ := io.ReadAll(resp.Body)
-	err = json.Unmarshal(re, &res)
-	if err != nil {
-		log.Printf("Error decoding response from url %v", err)
-	}
-
 	return nil
 }
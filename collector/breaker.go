@@ -0,0 +1,143 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+const (
+	defaultBreakerThreshold   = 3
+	defaultBreakerCooldown    = 30 * time.Second
+	defaultBreakerMaxCooldown = 10 * time.Minute
+	breakerProbeTimeout       = 10 * time.Second
+)
+
+var (
+	breakers          = map[string]*breaker{}
+	breakersMu        sync.Mutex
+	breakerThreshold  = defaultBreakerThreshold
+	breakerCooldown   = defaultBreakerCooldown
+	breakerMaxBackoff = defaultBreakerMaxCooldown
+)
+
+// breaker fails requests to a bmc fast after consecutive failures, the cool-down
+// doubles every time the probe of the service root fails
+type breaker struct {
+	mu        sync.Mutex
+	state     int
+	failures  int
+	trips     int64
+	cooldown  time.Duration
+	openUntil time.Time
+}
+
+func breakerOf(host string) *breaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	b, ok := breakers[host]
+	if !ok {
+		b = &breaker{cooldown: breakerCooldown}
+		breakers[host] = b
+	}
+	return b
+}
+
+// allow reports whether a request may be sent, probe is set for the single
+// request that is let through once the cool-down is over. Nothing probes on its own,
+// the breaker stays open until a request arrives after the cool-down
+func (b *breaker) allow() (allowed, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if time.Now().Before(b.openUntil) {
+			return false, false
+		}
+		b.state = breakerHalfOpen
+		return true, true
+	case breakerHalfOpen:
+		return false, false
+	}
+	return true, false
+}
+
+func (b *breaker) record(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ok {
+		b.state = breakerClosed
+		b.failures = 0
+		b.cooldown = breakerCooldown
+		return
+	}
+
+	b.failures++
+	switch {
+	case b.state == breakerHalfOpen:
+		b.cooldown *= 2
+		if b.cooldown > breakerMaxBackoff {
+			b.cooldown = breakerMaxBackoff
+		}
+	case b.state == breakerClosed && b.failures >= breakerThreshold:
+		b.trips++
+	default:
+		return
+	}
+	b.state = breakerOpen
+	b.openUntil = time.Now().Add(b.cooldown)
+}
+
+func (b *breaker) stats() (state, failures int, trips int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state, b.failures, b.trips
+}
+
+func (client *Client) allow(b *breaker) error {
+	allowed, probe := b.allow()
+	if !allowed {
+		return fmt.Errorf("circuit breaker of %s is open", client.host)
+	}
+	if !probe {
+		return nil
+	}
+
+	err := client.probe()
+	b.record(err == nil)
+	if err != nil {
+		return fmt.Errorf("circuit breaker of %s is open, probe failed: %v", client.host, err)
+	}
+	return nil
+}
+
+// probe checks whether the service root of the bmc answers again
+func (client *Client) probe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), breakerProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+client.host+redfishRootPath, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/json")
+
+	release := requestScheduler.acquire(client.host)
+	defer release()
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	// each step either records a result or expires the cool-down, then checks what allow returns
+	type step struct {
+		record   string // "ok", "fail" or empty to record nothing
+		expire   bool   // end the cool-down before calling allow
+		allowed  bool
+		probe    bool
+		state    int
+		cooldown time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"stays closed below the threshold", []step{
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "ok", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+		}},
+		{"opens at the threshold", []step{
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: false, state: breakerOpen, cooldown: breakerCooldown},
+		}},
+		{"lets one probe through and closes on success", []step{
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: false, state: breakerOpen, cooldown: breakerCooldown},
+			{expire: true, allowed: true, probe: true, state: breakerHalfOpen, cooldown: breakerCooldown},
+			{record: "ok", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+		}},
+		{"doubles the cool-down when the probe fails", []step{
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: true, state: breakerClosed, cooldown: breakerCooldown},
+			{record: "fail", allowed: false, state: breakerOpen, cooldown: breakerCooldown},
+			{expire: true, allowed: true, probe: true, state: breakerHalfOpen, cooldown: breakerCooldown},
+			{record: "fail", allowed: false, state: breakerOpen, cooldown: 2 * breakerCooldown},
+			{expire: true, allowed: true, probe: true, state: breakerHalfOpen, cooldown: 2 * breakerCooldown},
+			{record: "fail", allowed: false, state: breakerOpen, cooldown: 4 * breakerCooldown},
+		}},
+	}
+	for _, tt := range tests {
+		b := &breaker{cooldown: breakerCooldown}
+		for i, s := range tt.steps {
+			switch s.record {
+			case "ok":
+				b.record(true)
+			case "fail":
+				b.record(false)
+			}
+			if s.expire {
+				b.openUntil = time.Now().Add(-time.Second)
+			}
+			allowed, probe := b.allow()
+			state, _, _ := b.stats()
+			if allowed != s.allowed || probe != s.probe || state != s.state || b.cooldown != s.cooldown {
+				t.Errorf("%s: step %d allow() = %v, %v state %d cooldown %v, want %v, %v state %d cooldown %v",
+					tt.name, i, allowed, probe, state, b.cooldown, s.allowed, s.probe, s.state, s.cooldown)
+			}
+		}
+	}
+}
+
+func TestBreakerMaxCooldown(t *testing.T) {
+	b := &breaker{state: breakerHalfOpen, cooldown: breakerMaxBackoff}
+	b.record(false)
+	if b.cooldown != breakerMaxBackoff {
+		t.Errorf("cooldown = %v, want it capped at %v", b.cooldown, breakerMaxBackoff)
+	}
+	if _, _, trips := b.stats(); trips != 0 {
+		t.Errorf("a failed probe counted as a trip, trips = %d", trips)
+	}
+}
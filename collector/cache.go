@@ -0,0 +1,182 @@
+package collector
+
+import (
+	"github.com/patrickmn/go-cache"
+	"log"
+	"server_exporter/tools"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTtl     = 60 * time.Second
+	defaultCacheTimeout = 20 * time.Second
+	// clients are dropped after an hour so the endpoints get discovered again
+	clientTtl = time.Hour
+)
+
+var (
+	clients  = cache.New(clientTtl, 10*time.Minute)
+	results  = cache.New(cache.NoExpiration, 10*time.Minute)
+	inflight = map[string]*refreshCall{}
+	flightMu sync.Mutex
+)
+
+type cachedResult struct {
+	lines     []string
+	fetchedAt time.Time
+}
+
+type refreshCall struct {
+	done  chan struct{}
+	lines []string
+	err   error
+}
+
+// clientOf returns the client kept for the host, a new one is made when the credentials changed
+func clientOf(authInfo tools.Data, deviceName string) *Client {
+	if c, found := clients.Get(authInfo.Dat.Host); found {
+		client := c.(*Client)
+		if client.username == authInfo.Dat.Account && client.password == authInfo.Dat.Password {
+			client.setDeviceName(deviceName)
+			return client
+		}
+	}
+	client := newClient(authInfo, deviceName)
+	clients.SetDefault(authInfo.Dat.Host, client)
+	return client
+}
+
+// GetClient returns the cached client of the host with its endpoints discovered
+func GetClient(authInfo tools.Data, deviceName string) (*Client, error) {
+	client := clientOf(authInfo, deviceName)
+	err := client.ensureEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (collector *Collector) cacheTtl(name string) time.Duration {
+	conf := collector.config.Cache
+	if ttl, ok := conf.Ttls[name]; ok && ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+	if conf.Ttl > 0 {
+		return time.Duration(conf.Ttl) * time.Second
+	}
+	return defaultCacheTtl
+}
+
+// collectCached serves the result of a collector from the cache, refreshing it ahead of expiry
+// in the background and falling back to the last good result when the bmc is slow or failing.
+// A failed refresh with nothing to fall back to emits nothing and returns the error.
+func (collector *Collector) collectCached(name string, refresh refreshFunc) error {
+	conf := collector.config.Cache
+	key := collector.client.host + "/" + name
+	ttl := collector.cacheTtl(name)
+
+	var stale *cachedResult
+	if c, found := results.Get(key); found {
+		stale = c.(*cachedResult)
+		age := time.Since(stale.fetchedAt)
+		if age < ttl {
+			if conf.RefreshAhead > 0 && age >= time.Duration(conf.RefreshAhead*float64(ttl)) {
+				collector.startRefresh(key, name, refresh)
+			}
+			collector.emitCached(name, stale)
+			return nil
+		}
+		if !conf.ServeStale || (conf.MaxStale > 0 && age-ttl > time.Duration(conf.MaxStale)*time.Second) {
+			stale = nil
+		}
+	}
+
+	timeout := defaultCacheTimeout
+	if conf.Timeout > 0 {
+		timeout = time.Duration(conf.Timeout) * time.Second
+	}
+	call := collector.startRefresh(key, name, refresh)
+	if stale != nil {
+		select {
+		case <-call.done:
+		case <-time.After(timeout):
+			log.Printf("%s metrics of %s are slow, serving stale result", name, collector.client.host)
+			collector.emitCached(name, stale)
+			return nil
+		}
+	} else {
+		<-call.done
+	}
+
+	if call.err != nil {
+		if stale == nil {
+			return call.err
+		}
+		collector.emitCached(name, stale)
+		return nil
+	}
+	for _, line := range call.lines {
+		collector.ch <- line
+	}
+	collector.ch <- collector.NewDataAge(name, 0)
+	return nil
+}
+
+func (collector *Collector) emitCached(name string, res *cachedResult) {
+	for _, line := range res.lines {
+		collector.ch <- line
+	}
+	collector.ch <- collector.NewDataAge(name, time.Since(res.fetchedAt).Seconds())
+}
+
+// startRefresh runs the collector unless a refresh of the same key is already in flight
+func (collector *Collector) startRefresh(key, name string, refresh refreshFunc) *refreshCall {
+	flightMu.Lock()
+	defer flightMu.Unlock()
+	if call, ok := inflight[key]; ok {
+		return call
+	}
+
+	call := &refreshCall{done: make(chan struct{})}
+	inflight[key] = call
+	go func() {
+		call.lines, call.err = collector.runRefresh(refresh)
+		if call.err != nil {
+			log.Printf("fail to refresh cached %s metrics of %s-%s", name, collector.client.host, call.err)
+		} else {
+			expiration := cache.NoExpiration
+			if maxStale := collector.config.Cache.MaxStale; maxStale > 0 {
+				expiration = collector.cacheTtl(name) + time.Duration(maxStale)*time.Second
+			}
+			results.Set(key, &cachedResult{lines: call.lines, fetchedAt: time.Now()}, expiration)
+		}
+
+		flightMu.Lock()
+		delete(inflight, key)
+		flightMu.Unlock()
+		close(call.done)
+	}()
+	return call
+}
+
+func (collector *Collector) runRefresh(refresh refreshFunc) ([]string, error) {
+	err := collector.client.ensureEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan string, 100)
+	var lines []string
+	drained := make(chan struct{})
+	go func() {
+		for line := range ch {
+			lines = append(lines, line)
+		}
+		close(drained)
+	}()
+	err = refresh(collector, ch)
+	close(ch)
+	<-drained
+	return lines, err
+}
@@ -13,100 +13,117 @@ type Collector struct {
 	ch     chan string
 }
 
-func NewCollector(authInfo tools.Data, deviceName string, conf config.Config, ch chan string) *Collector {
-	client, err := NewClient(authInfo, deviceName)
-	if err != nil {
-		log.Fatalf("NewClient err: %v", err)
-		return nil
+type refreshFunc func(mc *Collector, ch chan string) error
+
+type refresher struct {
+	name    string
+	refresh refreshFunc
+}
+
+func NewCollector(authInfo tools.Data, deviceName string, conf config.Config, ch chan string) (*Collector, error) {
+	client := clientOf(authInfo, deviceName)
+	// with caching enabled the endpoints are discovered lazily so a dead bmc can still serve stale data
+	if !conf.Cache.Enabled {
+		err := client.ensureEndpoints()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &Collector{
 		client: client,
 		config: conf,
 		ch:     ch,
+	}, nil
+}
+
+func (collector *Collector) refreshers() []refresher {
+	var refreshers []refresher
+	metrics := collector.config.Metrics
+	client := collector.client
+
+	if metrics.System {
+		refreshers = append(refreshers, refresher{"system", client.RefreshSystem})
+	}
+	if metrics.Sensors {
+		refreshers = append(refreshers, refresher{"sensors", client.RefreshSensors})
+	}
+	if metrics.Power {
+		refreshers = append(refreshers, refresher{"power", client.RefreshPower})
+	}
+	if metrics.Network {
+		refreshers = append(refreshers, refresher{"network", client.RefreshNetwork})
+	}
+	if metrics.Sel {
+		refreshers = append(refreshers, refresher{"sel", client.RefreshIdracSel})
+	}
+	if metrics.Storage {
+		refreshers = append(refreshers, refresher{"storage", client.RefreshStorage})
 	}
+	if metrics.Memory {
+		refreshers = append(refreshers, refresher{"memory", client.RefreshMemory})
+	}
+	if metrics.Processors {
+		refreshers = append(refreshers, refresher{"processors", client.RefreshProcessors})
+	}
+	if metrics.Pcie {
+		refreshers = append(refreshers, refresher{"pcie", client.RefreshPcie})
+	}
+	if metrics.Telemetry {
+		refreshers = append(refreshers, refresher{"telemetry", client.RefreshTelemetry})
+	}
+	return refreshers
 }
 
 func (collector *Collector) Collect() {
 	var wg sync.WaitGroup
 
-	if collector.config.Metrics.System {
+	for _, r := range collector.refreshers() {
 		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshSystem(collector, collector.ch)
-			if err != nil {
-				log.Printf("fail to collect system metrics-%s", err)
+		go func(r refresher) {
+			defer wg.Done()
+			var err error
+			if collector.config.Cache.Enabled {
+				err = collector.collectCached(r.name, r.refresh)
+			} else {
+				err = r.refresh(collector, collector.ch)
 			}
-			wg.Done()
-		}()
-	}
-
-	if collector.config.Metrics.Sensors {
-		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshSensors(collector, collector.ch)
 			if err != nil {
-				log.Printf("fail to collect sensors metrics-%s", err)
+				log.Printf("fail to collect %s metrics-%s", r.name, err)
 			}
-			wg.Done()
-		}()
+		}(r)
 	}
 
-	if collector.config.Metrics.Power {
-		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshPower(collector, collector.ch)
-			if err != nil {
-				log.Printf("fail to collect power metrics-%s", err)
-			}
-			wg.Done()
-		}()
-	}
+	wg.Wait()
 
-	if collector.config.Metrics.Network {
-		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshNetwork(collector, collector.ch)
-			if err != nil {
-				log.Printf("fail to collect network metrics-%s", err)
-			}
-			wg.Done()
-		}()
-	}
+	requests, inFlight, wait := requestScheduler.stats(collector.client.host)
+	collector.ch <- collector.NewSchedulerRequests(requests)
+	collector.ch <- collector.NewSchedulerInFlight(inFlight)
+	collector.ch <- collector.NewSchedulerQueueWait(wait)
 
-	if collector.config.Metrics.Sel {
-		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshIdracSel(collector, collector.ch)
-			if err != nil {
-				log.Printf("fail to collect sel metrics-%s", err)
-			}
-			wg.Done()
-		}()
-	}
+	state, failures, trips := breakerOf(collector.client.host).stats()
+	collector.ch <- collector.NewBreakerState(state)
+	collector.ch <- collector.NewBreakerFailures(failures)
+	collector.ch <- collector.NewBreakerTrips(trips)
 
-	if collector.config.Metrics.Storage {
-		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshStorage(collector, collector.ch)
-			if err != nil {
-				log.Printf("fail to collect storage metrics-%s", err)
-			}
-			wg.Done()
-		}()
+	codes, stats := retryStats(collector.client.host)
+	for _, code := range codes {
+		collector.ch <- collector.NewRequestRetries(code, stats[code])
 	}
 
-	if collector.config.Metrics.Memory {
-		wg.Add(1)
-		go func() {
-			err := collector.client.RefreshMemory(collector, collector.ch)
-			if err != nil {
-				log.Printf("fail to collect memory metrics-%s", err)
-			}
-			wg.Done()
-		}()
+	if collector.config.Events.Subscribe {
+		collector.ch <- collector.NewEventSubscriptionActive(subscribed(collector.client.host))
+	}
+	keys, received := eventStats(collector.client.host)
+	for _, k := range keys {
+		collector.ch <- collector.NewEventsReceived(k.eventType, k.severity, received[k])
 	}
 
-	wg.Wait()
-
+	if connected, connects, ok := streamStats(collector.client.host); ok {
+		collector.ch <- collector.NewEventStreamConnected(connected)
+		collector.ch <- collector.NewEventStreamConnects(connects)
+	}
+	for _, report := range storedMetricReports(collector.client.host, collector.metricReportMaxAge()) {
+		collector.emitMetricReport(collector.ch, report)
+	}
 }
@@ -0,0 +1,346 @@
+package collector
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"path"
+	"server_exporter/config"
+	"server_exporter/tools"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eventContext marks the subscriptions of the exporter, the host in it tells the receiver
+// which bmc pushed an event and the token after it proves the event came from that subscription
+const eventContextPrefix = "server_exporter:"
+
+var (
+	events          = map[string]map[eventKey]int64{}
+	eventsMu        sync.Mutex
+	subscriptions   = map[string]*subscription{}
+	subscriptionsMu sync.Mutex
+)
+
+type eventKey struct {
+	eventType string
+	severity  string
+}
+
+type subscription struct {
+	client *Client
+	path   string
+	token  string
+}
+
+func eventContext(host, token string) string {
+	return eventContextPrefix + host + ";" + token
+}
+
+// parseEventContext splits the Context of a pushed event, events of other subscriptions give no host
+func parseEventContext(ctx string) (host, token string) {
+	if !strings.HasPrefix(ctx, eventContextPrefix) {
+		return "", ""
+	}
+	host, token, _ = strings.Cut(strings.TrimPrefix(ctx, eventContextPrefix), ";")
+	return host, token
+}
+
+func newEventToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func countEvent(host, eventType, severity string) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if events[host] == nil {
+		events[host] = map[eventKey]int64{}
+	}
+	events[host][eventKey{eventType, severity}]++
+}
+
+func eventStats(host string) ([]eventKey, map[eventKey]int64) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	stats := make(map[eventKey]int64, len(events[host]))
+	keys := make([]eventKey, 0, len(events[host]))
+	for k, n := range events[host] {
+		stats[k] = n
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].eventType != keys[j].eventType {
+			return keys[i].eventType < keys[j].eventType
+		}
+		return keys[i].severity < keys[j].severity
+	})
+	return keys, stats
+}
+
+func subscribed(host string) bool {
+	subscriptionsMu.Lock()
+	defer subscriptionsMu.Unlock()
+	return subscriptions[host] != nil
+}
+
+// Subscribe makes sure the bmc has exactly one EventDestination of the exporter, it reuses the
+// subscription left by a previous run and deletes duplicates
+func Subscribe(authInfo tools.Data, deviceName string, conf config.Events) error {
+	client := clientOf(authInfo, deviceName)
+	err := client.ensureEndpoints()
+	if err == nil {
+		var path, token string
+		path, token, err = client.subscribe(conf.Destination)
+		if err == nil {
+			subscriptionsMu.Lock()
+			subscriptions[client.host] = &subscription{client: client, path: path, token: token}
+			subscriptionsMu.Unlock()
+			return nil
+		}
+	}
+
+	subscriptionsMu.Lock()
+	delete(subscriptions, client.host)
+	subscriptionsMu.Unlock()
+	return err
+}
+
+// Unsubscribe deletes the subscriptions made by Subscribe, the deletes run without the lock
+// so a slow bmc doesn't hold up Subscribe
+func Unsubscribe() {
+	subscriptionsMu.Lock()
+	subs := subscriptions
+	subscriptions = map[string]*subscription{}
+	subscriptionsMu.Unlock()
+
+	for host, s := range subs {
+		if err := s.client.redfishDelete(s.path); err != nil {
+			log.Printf("fail to delete event subscription of %s-%s", host, err)
+		}
+	}
+}
+
+// subscribe returns the location of the subscription and the token in its Context
+func (client *Client) subscribe(destination string) (string, string, error) {
+	if client.eventServicePath == "" {
+		return "", "", errors.New("bmc has no event service")
+	}
+	var service EventServiceResponse
+	err := client.redfishGet(client.eventServicePath, &service)
+	if err != nil {
+		return "", "", err
+	}
+	if !service.ServiceEnabled {
+		return "", "", errors.New("event service is disabled")
+	}
+
+	var group GroupResponse
+	err = client.redfishGet(service.Subscriptions.OdataId, &group)
+	if err != nil {
+		return "", "", err
+	}
+	keep, token := "", ""
+	for _, c := range group.Members {
+		var dest EventDestination
+		if err = client.redfishGet(c.OdataId, &dest); err != nil {
+			log.Println(err)
+			continue
+		}
+		// subscriptions pointing elsewhere belong to other receivers, e.g. another exporter replica
+		if dest.Destination != destination {
+			continue
+		}
+		host, destToken := parseEventContext(dest.Context)
+		if keep == "" && host == client.host && destToken != "" {
+			keep, token = c.OdataId, destToken
+			continue
+		}
+		if err = client.redfishDelete(c.OdataId); err != nil {
+			log.Printf("fail to delete stale event subscription %s-%s", c.OdataId, err)
+		}
+	}
+	if keep != "" {
+		return keep, token, nil
+	}
+
+	token, err = newEventToken()
+	if err != nil {
+		return "", "", err
+	}
+	dest := EventDestination{Destination: destination, Context: eventContext(client.host, token), Protocol: "Redfish"}
+	location, err := client.redfishPost(service.Subscriptions.OdataId, dest)
+	if err != nil {
+		// bmcs before redfish 1.6 insist on the deprecated EventTypes
+		dest.EventTypes = subscriptionEventTypes(service.EventTypesForSubscription)
+		location, err = client.redfishPost(service.Subscriptions.OdataId, dest)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if location == "" {
+		return "", "", fmt.Errorf("bmc returned no location for the subscription")
+	}
+	return location, token, nil
+}
+
+// subscriptionEventTypes lists the event types the exporter counts, limited to the ones the
+// bmc advertises when it does
+func subscriptionEventTypes(supported []string) []string {
+	wanted := []string{"Alert", "ResourceEvent", "StatusChange"}
+	if len(supported) == 0 {
+		return wanted
+	}
+	var types []string
+	for _, t := range wanted {
+		for _, s := range supported {
+			if s == t {
+				types = append(types, t)
+				break
+			}
+		}
+	}
+	if len(types) == 0 {
+		return wanted
+	}
+	return types
+}
+
+// eventSeverity prefers the MessageSeverity that replaced Severity in newer schemas
+func (e EventRecord) eventSeverity() string {
+	if e.MessageSeverity != "" {
+		return selSeverity(e.MessageSeverity)
+	}
+	return selSeverity(e.Severity)
+}
+
+// component names the resource an event is about
+func (e EventRecord) component() string {
+	if e.OriginOfCondition.OdataId != "" {
+		return path.Base(strings.TrimSuffix(e.OriginOfCondition.OdataId, "/"))
+	}
+	return e.MessageId
+}
+
+func (e EventRecord) eventType() string {
+	if e.EventType == "" {
+		return "Event"
+	}
+	return e.EventType
+}
+
+func (e EventRecord) record(source, host, deviceName string) tools.LogRecord {
+	return tools.LogRecord{
+		Source:     source,
+		Host:       host,
+		DeviceName: deviceName,
+		Severity:   e.eventSeverity(),
+		Component:  e.component(),
+		Id:         e.EventId,
+		Message:    e.Message,
+		Time:       parseLogTime(e.EventTimestamp),
+	}
+}
+
+// handleEvents counts the events of a payload and forwards them to the log outputs
+func handleEvents(outputs config.Outputs, source, host, deviceName string, payload *EventPayload) {
+	records := make([]tools.LogRecord, 0, len(payload.Events))
+	for _, e := range payload.Events {
+		countEvent(host, e.eventType(), e.eventSeverity())
+		records = append(records, e.record(source, host, deviceName))
+	}
+	forwardLogs(outputs, records)
+}
+
+// eventReceiver accepts the events pushed to the EventDestination
+type eventReceiver struct {
+	conf config.Config
+}
+
+func (rcv *eventReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var payload EventPayload
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&payload)
+	if err != nil {
+		log.Printf("fail to decode pushed event-%s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	host, token := parseEventContext(payload.Context)
+	remote, _, _ := net.SplitHostPort(r.RemoteAddr)
+	if host == "" {
+		host = remote
+	}
+	deviceName, ok := rcv.authorize(host, token, remote)
+	if !ok {
+		log.Printf("reject event for %q pushed from %s", host, r.RemoteAddr)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	handleEvents(rcv.conf.Outputs, "event", host, deviceName, &payload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorize accepts the events of a subscribed or configured host that carry the token of the
+// subscription or come from the host itself, it returns the device name of the host
+func (rcv *eventReceiver) authorize(host, token, remote string) (string, bool) {
+	subscriptionsMu.Lock()
+	s := subscriptions[host]
+	subscriptionsMu.Unlock()
+	if s != nil {
+		if s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return s.client.getDeviceName(), true
+		}
+		return s.client.getDeviceName(), remote == host
+	}
+	if _, configured := rcv.conf.Hosts[host]; configured && remote == host {
+		return "", true
+	}
+	return "", false
+}
+
+// StartEventReceiver runs the https listener of the pushed events in the background
+func StartEventReceiver(conf config.Config) error {
+	var cert tls.Certificate
+	var err error
+	if conf.Events.CertFile != "" {
+		cert, err = tls.LoadX509KeyPair(conf.Events.CertFile, conf.Events.KeyFile)
+	} else {
+		cert, err = tools.SelfSignedCert()
+	}
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              conf.Events.Listen,
+		Handler:           &eventReceiver{conf: conf},
+		TLSConfig:         &tls.Config{Certificates: []tls.Certificate{cert}},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	listener, err := tls.Listen("tcp", conf.Events.Listen, server.TLSConfig)
+	if err != nil {
+		return err
+	}
+	go func() {
+		log.Printf("event receiver stopped-%s", server.Serve(listener))
+	}()
+	return nil
+}
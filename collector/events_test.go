@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"server_exporter/config"
+	"strings"
+	"testing"
+)
+
+func TestParseEventContext(t *testing.T) {
+	tests := []struct {
+		ctx   string
+		host  string
+		token string
+	}{
+		{eventContext("10.0.0.1", "abc"), "10.0.0.1", "abc"},
+		{"server_exporter:10.0.0.1", "10.0.0.1", ""},
+		{"other subscriber", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		host, token := parseEventContext(tt.ctx)
+		if host != tt.host || token != tt.token {
+			t.Errorf("parseEventContext(%q) = %q, %q, want %q, %q", tt.ctx, host, token, tt.host, tt.token)
+		}
+	}
+}
+
+func TestEventReceiverAuthorization(t *testing.T) {
+	subscriptionsMu.Lock()
+	subscriptions["10.0.0.1"] = &subscription{client: &Client{host: "10.0.0.1"}, token: "secret"}
+	subscriptionsMu.Unlock()
+	defer func() {
+		subscriptionsMu.Lock()
+		delete(subscriptions, "10.0.0.1")
+		subscriptionsMu.Unlock()
+	}()
+
+	rcv := &eventReceiver{conf: config.Config{Hosts: map[string]config.Hosts{"10.0.0.2": {}}}}
+	tests := []struct {
+		name    string
+		context string
+		remote  string
+		want    int
+	}{
+		{"subscription token", eventContext("10.0.0.1", "secret"), "192.168.0.9:5000", http.StatusNoContent},
+		{"wrong token", eventContext("10.0.0.1", "guess"), "192.168.0.9:5000", http.StatusForbidden},
+		{"wrong token from the bmc", eventContext("10.0.0.1", "old"), "10.0.0.1:5000", http.StatusNoContent},
+		{"configured host", "", "10.0.0.2:5000", http.StatusNoContent},
+		{"configured host from elsewhere", eventContext("10.0.0.2", ""), "192.168.0.9:5000", http.StatusForbidden},
+		{"unknown host", eventContext("10.9.9.9", "x"), "10.9.9.9:5000", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		body := `{"Context":"` + tt.context + `","Events":[{"EventType":"Alert","Severity":"Critical","Message":"m"}]}`
+		req := httptest.NewRequest("POST", "/redfish/events", strings.NewReader(body))
+		req.RemoteAddr = tt.remote
+		w := httptest.NewRecorder()
+		rcv.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
+
+func TestSubscriptionEventTypes(t *testing.T) {
+	tests := []struct {
+		supported []string
+		want      string
+	}{
+		{nil, "Alert,ResourceEvent,StatusChange"},
+		{[]string{"StatusChange", "Alert", "MetricReport"}, "Alert,StatusChange"},
+		{[]string{"Alert"}, "Alert"},
+		{[]string{"MetricReport"}, "Alert,ResourceEvent,StatusChange"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(subscriptionEventTypes(tt.supported), ","); got != tt.want {
+			t.Errorf("subscriptionEventTypes(%v) = %s, want %s", tt.supported, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package collector
+
+import (
+	"log"
+)
+
+// Inventory is the structured hardware document served by the inventory api
+type Inventory struct {
+	Host              string                      `json:"host"`
+	DeviceName        string                      `json:"deviceName"`
+	System            InventorySystem             `json:"system"`
+	Processors        []InventoryProcessor        `json:"processors"`
+	Memory            []InventoryMemory           `json:"memory"`
+	Controllers       []InventoryController       `json:"controllers"`
+	Drives            []InventoryDrive            `json:"drives"`
+	NetworkInterfaces []InventoryNetworkInterface `json:"networkInterfaces"`
+	PowerSupplies     []InventoryPowerSupply      `json:"powerSupplies"`
+}
+
+type InventorySystem struct {
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	SerialNumber string `json:"serialNumber"`
+	PartNumber   string `json:"partNumber"`
+	SKU          string `json:"sku"`
+	UUID         string `json:"uuid"`
+	AssetTag     string `json:"assetTag"`
+	HostName     string `json:"hostName"`
+	BiosVersion  string `json:"biosVersion"`
+	PowerState   string `json:"powerState"`
+	Health       string `json:"health"`
+}
+
+type InventoryProcessor struct {
+	Id                string `json:"id"`
+	Socket            string `json:"socket"`
+	Type              string `json:"type"`
+	Manufacturer      string `json:"manufacturer"`
+	Model             string `json:"model"`
+	SerialNumber      string `json:"serialNumber"`
+	PartNumber        string `json:"partNumber"`
+	Microcode         string `json:"microcode"`
+	TotalCores        int    `json:"totalCores"`
+	TotalThreads      int    `json:"totalThreads"`
+	MaxSpeedMHz       int    `json:"maxSpeedMHz"`
+	OperatingSpeedMHz int    `json:"operatingSpeedMHz"`
+	Health            string `json:"health"`
+}
+
+type InventoryMemory struct {
+	Id           string `json:"id"`
+	Locator      string `json:"locator"`
+	Manufacturer string `json:"manufacturer"`
+	Type         string `json:"type"`
+	SerialNumber string `json:"serialNumber"`
+	PartNumber   string `json:"partNumber"`
+	Firmware     string `json:"firmware"`
+	CapacityMiB  int    `json:"capacityMiB"`
+	SpeedMHz     int    `json:"speedMHz"`
+	Health       string `json:"health"`
+}
+
+type InventoryController struct {
+	Storage      string  `json:"storage"`
+	Name         string  `json:"name"`
+	Manufacturer string  `json:"manufacturer"`
+	Model        string  `json:"model"`
+	SerialNumber string  `json:"serialNumber"`
+	PartNumber   string  `json:"partNumber"`
+	Firmware     string  `json:"firmware"`
+	SpeedGbps    float64 `json:"speedGbps"`
+	Health       string  `json:"health"`
+}
+
+type InventoryDrive struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Manufacturer  string `json:"manufacturer"`
+	Model         string `json:"model"`
+	SerialNumber  string `json:"serialNumber"`
+	PartNumber    string `json:"partNumber"`
+	Firmware      string `json:"firmware"`
+	MediaType     string `json:"mediaType"`
+	Protocol      string `json:"protocol"`
+	CapacityBytes int    `json:"capacityBytes"`
+	Slot          int    `json:"slot"`
+	Health        string `json:"health"`
+}
+
+type InventoryNetworkInterface struct {
+	Id           string                 `json:"id"`
+	Manufacturer string                 `json:"manufacturer"`
+	Model        string                 `json:"model"`
+	SerialNumber string                 `json:"serialNumber"`
+	PartNumber   string                 `json:"partNumber"`
+	Firmware     string                 `json:"firmware"`
+	Health       string                 `json:"health"`
+	Ports        []InventoryNetworkPort `json:"ports"`
+}
+
+type InventoryNetworkPort struct {
+	Id         string   `json:"id"`
+	Addresses  []string `json:"addresses"`
+	LinkStatus string   `json:"linkStatus"`
+	SpeedMbps  int      `json:"speedMbps"`
+}
+
+type InventoryPowerSupply struct {
+	Id              string  `json:"id"`
+	Name            string  `json:"name"`
+	Manufacturer    string  `json:"manufacturer"`
+	Model           string  `json:"model"`
+	SerialNumber    string  `json:"serialNumber"`
+	PartNumber      string  `json:"partNumber"`
+	SparePartNumber string  `json:"sparePartNumber"`
+	Firmware        string  `json:"firmware"`
+	CapacityWatts   float64 `json:"capacityWatts"`
+	Health          string  `json:"health"`
+}
+
+// Inventory walks the system and its components, a failing component is logged and left out
+func (client *Client) Inventory() (*Inventory, error) {
+	var system SystemResponse
+	err := client.redfishGet(client.systemPath, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &Inventory{
+		Host:       client.host,
+		DeviceName: client.getDeviceName(),
+		System: InventorySystem{
+			Manufacturer: system.Manufacturer,
+			Model:        system.Model,
+			SerialNumber: system.SerialNumber,
+			PartNumber:   system.PartNumber,
+			SKU:          system.SKU,
+			UUID:         system.UUID,
+			AssetTag:     system.AssetTag,
+			HostName:     system.HostName,
+			BiosVersion:  system.BiosVersion,
+			PowerState:   system.PowerState,
+			Health:       system.Status.Health,
+		},
+	}
+
+	if err = client.inventoryProcessors(inv, system.Processors.OdataId); err != nil {
+		log.Printf("fail to collect processor inventory-%s", err)
+	}
+	if err = client.inventoryMemory(inv); err != nil {
+		log.Printf("fail to collect memory inventory-%s", err)
+	}
+	if err = client.inventoryStorage(inv); err != nil {
+		log.Printf("fail to collect storage inventory-%s", err)
+	}
+	if err = client.inventoryNetwork(inv); err != nil {
+		log.Printf("fail to collect network inventory-%s", err)
+	}
+	if err = client.inventoryPower(inv); err != nil {
+		log.Printf("fail to collect power inventory-%s", err)
+	}
+	return inv, nil
+}
+
+func (client *Client) inventoryProcessors(inv *Inventory, path string) error {
+	var group GroupResponse
+	err := client.redfishGet(path, &group)
+	if err != nil {
+		return err
+	}
+	for _, c := range group.Members {
+		var p Processor
+		if err = client.redfishGet(c.OdataId, &p); err != nil {
+			log.Println(err)
+			continue
+		}
+		if p.Status.State == StateAbsent {
+			continue
+		}
+		item := InventoryProcessor{
+			Id:                p.Id,
+			Socket:            p.Socket,
+			Type:              p.ProcessorType,
+			Manufacturer:      p.Manufacturer,
+			Model:             p.Model,
+			SerialNumber:      p.SerialNumber,
+			PartNumber:        p.PartNumber,
+			TotalCores:        p.TotalCores,
+			TotalThreads:      p.TotalThreads,
+			MaxSpeedMHz:       p.MaxSpeedMHz,
+			OperatingSpeedMHz: p.OperatingSpeedMHz,
+			Health:            p.Status.Health,
+		}
+		if p.ProcessorId != nil {
+			item.Microcode = p.ProcessorId.MicrocodeInfo
+		}
+		inv.Processors = append(inv.Processors, item)
+	}
+	return nil
+}
+
+func (client *Client) inventoryMemory(inv *Inventory) error {
+	var group GroupResponse
+	err := client.redfishGet(client.memoryPath, &group)
+	if err != nil {
+		return err
+	}
+	for _, c := range group.Members {
+		m, err := client.getMemory(c.OdataId)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if m.Status.State == StateAbsent {
+			continue
+		}
+		inv.Memory = append(inv.Memory, InventoryMemory{
+			Id:           m.Id,
+			Locator:      m.DeviceLocator,
+			Manufacturer: m.Manufacturer,
+			Type:         m.MemoryDeviceType,
+			SerialNumber: m.SerialNumber,
+			PartNumber:   m.PartNumber,
+			Firmware:     m.FirmwareRevision,
+			CapacityMiB:  m.CapacityMiB,
+			SpeedMHz:     m.OperatingSpeedMhz,
+			Health:       m.Status.Health,
+		})
+	}
+	return nil
+}
+
+func (client *Client) inventoryStorage(inv *Inventory) error {
+	ctlrs, drives, err := client.profile.Storage(client)
+	if err != nil {
+		return err
+	}
+	for _, s := range ctlrs {
+		for _, c := range s.StorageControllers {
+			inv.Controllers = append(inv.Controllers, InventoryController{
+				Storage:      s.Id,
+				Name:         c.Name,
+				Manufacturer: c.Manufacturer,
+				Model:        c.Model,
+				SerialNumber: c.SerialNumber,
+				PartNumber:   c.PartNumber,
+				Firmware:     c.FirmwareVersion,
+				SpeedGbps:    c.SpeedGbps,
+				Health:       c.Status.Health,
+			})
+		}
+	}
+	for _, d := range drives {
+		drive, err := client.getDrive(d.OdataId)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		inv.Drives = append(inv.Drives, InventoryDrive{
+			Id:            drive.Id,
+			Name:          drive.Name,
+			Manufacturer:  drive.Manufacturer,
+			Model:         drive.Model,
+			SerialNumber:  drive.SerialNumber,
+			PartNumber:    drive.PartNumber,
+			Firmware:      drive.Revision,
+			MediaType:     drive.MediaType,
+			Protocol:      drive.Protocol,
+			CapacityBytes: drive.CapacityBytes,
+			Slot:          drive.GetSlot(),
+			Health:        drive.Status.Health,
+		})
+	}
+	return nil
+}
+
+func (client *Client) inventoryNetwork(inv *Inventory) error {
+	var group GroupResponse
+	err := client.redfishGet(client.networkPath, &group)
+	if err != nil {
+		return err
+	}
+	for _, c := range group.Members {
+		var ni NetworkInterface
+		if err = client.redfishGet(c.OdataId, &ni); err != nil {
+			log.Println(err)
+			continue
+		}
+		client.profile.NormalizeNetworkInterface(&ni)
+		item := InventoryNetworkInterface{Id: ni.Id, Health: ni.Status.Health}
+		if ni.Links.NetworkAdapter.OdataId != "" {
+			var adapter NetworkAdapter
+			if err = client.redfishGet(ni.Links.NetworkAdapter.OdataId, &adapter); err != nil {
+				log.Println(err)
+			}
+			item.Manufacturer = adapter.Manufacturer
+			item.Model = adapter.Model
+			item.SerialNumber = adapter.SerialNumber
+			item.PartNumber = adapter.PartNumber
+			if len(adapter.Controllers) > 0 {
+				item.Firmware = adapter.Controllers[0].FirmwarePackageVersion
+			}
+		}
+
+		var ports GroupResponse
+		if err = client.redfishGet(ni.GetPorts(), &ports); err != nil {
+			log.Println(err)
+		}
+		for _, p := range ports.Members {
+			var port NetworkPort
+			if err = client.redfishGet(p.OdataId, &port); err != nil {
+				log.Println(err)
+				continue
+			}
+			item.Ports = append(item.Ports, InventoryNetworkPort{
+				Id:         port.Id,
+				Addresses:  port.AssociatedNetworkAddresses,
+				LinkStatus: port.LinkStatus,
+				SpeedMbps:  port.GetSpeed(),
+			})
+		}
+		inv.NetworkInterfaces = append(inv.NetworkInterfaces, item)
+	}
+	return nil
+}
+
+func (client *Client) inventoryPower(inv *Inventory) error {
+	var resp PowerResponse
+	err := client.redfishGet(client.powerPath, &resp)
+	if err != nil {
+		return err
+	}
+	for i, psu := range resp.PowerSupplies {
+		if psu.Status.State == StateAbsent {
+			continue
+		}
+		client.profile.NormalizePowerSupply(&psu)
+		inv.PowerSupplies = append(inv.PowerSupplies, InventoryPowerSupply{
+			// the id of the power supply metrics, so inventory and metrics join
+			Id:              psu.GetId(i),
+			Name:            psu.Name,
+			Manufacturer:    psu.Manufacturer,
+			Model:           psu.Model,
+			SerialNumber:    psu.SerialNumber,
+			PartNumber:      psu.PartNumber,
+			SparePartNumber: psu.SparePartNumber,
+			Firmware:        psu.FirmwareVersion,
+			CapacityWatts:   psu.PowerCapacityWatts,
+			Health:          psu.Status.Health,
+		})
+	}
+	return nil
+}
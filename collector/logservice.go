@@ -0,0 +1,156 @@
+package collector
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+var logTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"06-01-02 15:04:05",
+}
+
+// maxLogPages bounds the nextLink pages read from one log, a broken bmc may link a page to itself
+const maxLogPages = 100
+
+var errNoLogService = errors.New("no event log service found")
+
+// parseLogTime accepts the timestamp formats bmcs use for log entries
+func parseLogTime(s string) time.Time {
+	for _, layout := range logTimeLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// logEntries reads a standard LogEntry collection and follows its Members@odata.nextLink pages
+func (client *Client) logEntries(path string) ([]SelEntry, error) {
+	var entries []SelEntry
+	seen := map[string]bool{}
+	for page := 0; path != "" && !seen[path] && page < maxLogPages; page++ {
+		seen[path] = true
+		var resp LogEntryCollection
+		err := client.redfishGet(path, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range resp.Members {
+			st := string(e.SensorType)
+			if st == "" {
+				st = "Unknown"
+			}
+			entries = append(entries, SelEntry{Id: e.Id, Message: e.Message, Component: st, Severity: e.Severity, Created: parseLogTime(e.Created)})
+		}
+		path = resp.NextLink
+	}
+	return entries, nil
+}
+
+// logServices lists the LogServices collections of the system and of every manager
+func (client *Client) logServices() []string {
+	var paths []string
+	if client.logServicesPath != "" {
+		paths = append(paths, client.logServicesPath)
+	}
+
+	group := GroupResponse{}
+	err := client.redfishGet(client.managersPath, &group)
+	if err != nil {
+		log.Printf("fail to list managers-%s", err)
+		return paths
+	}
+	for _, c := range group.Members {
+		manager := ManagerResponse{}
+		if err = client.redfishGet(c.OdataId, &manager); err != nil {
+			log.Println(err)
+			continue
+		}
+		if manager.LogServices.OdataId != "" {
+			paths = append(paths, manager.LogServices.OdataId)
+		}
+	}
+	return paths
+}
+
+// logServiceRank orders the services by how likely they hold the hardware events,
+// 0 means the service is not an event log at all
+func logServiceRank(service *LogService) int {
+	id := strings.ToLower(service.Id)
+	switch {
+	case service.LogEntryType == "SEL" || id == "sel":
+		return 3
+	case id == "iml" || strings.Contains(id, "sel"):
+		return 2
+	case service.LogEntryType == "Event" || strings.Contains(id, "event"):
+		return 1
+	}
+	return 0
+}
+
+// discoverSelPath walks the log services once and remembers the entries of the best event log
+func (client *Client) discoverSelPath() (string, error) {
+	// the walk runs without client.mu, it may take several slow requests and would block the endpoint discovery
+	client.mu.Lock()
+	path, found := client.selPath, client.selFound
+	client.mu.Unlock()
+	if found {
+		return path, nil
+	}
+
+	best := 0
+	var lastErr error
+	for _, servicesPath := range client.logServices() {
+		group := GroupResponse{}
+		err := client.redfishGet(servicesPath, &group)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, c := range group.Members {
+			service := LogService{}
+			if err = client.redfishGet(c.OdataId, &service); err != nil {
+				lastErr = err
+				continue
+			}
+			if rank := logServiceRank(&service); rank > best && service.Entries.OdataId != "" {
+				best = rank
+				path = service.Entries.OdataId
+			}
+		}
+	}
+	// an incomplete walk that found nothing is retried on the next collection
+	if path == "" && lastErr != nil {
+		return "", lastErr
+	}
+	client.mu.Lock()
+	client.selPath = path
+	client.selFound = true
+	client.mu.Unlock()
+	return path, nil
+}
+
+// selEntries reads the discovered event log and falls back to the vendor paths in order
+func (client *Client) selEntries(fallbacks ...string) ([]SelEntry, error) {
+	path, err := client.discoverSelPath()
+	if err == nil && path != "" {
+		return client.logEntries(path)
+	}
+	if err == nil {
+		err = errNoLogService
+	}
+	for _, fallback := range fallbacks {
+		entries, fallbackErr := client.logEntries(fallback)
+		if fallbackErr == nil {
+			return entries, nil
+		}
+		err = fallbackErr
+	}
+	return nil, err
+}
@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"log"
+	"server_exporter/config"
+	"server_exporter/tools"
+	"sync"
+)
+
+// maxQueuedLogs bounds the records kept for a log output that is down, the oldest are dropped
+const maxQueuedLogs = 10000
+
+var (
+	logQueues   = map[string]*logQueue{}
+	logQueuesMu sync.Mutex
+)
+
+// logQueue holds the records of one log output, records the output fails to take are
+// queued again and go out with the next forward
+type logQueue struct {
+	name    string
+	mu      sync.Mutex
+	records []tools.LogRecord
+	sending bool
+}
+
+func logQueueOf(name string) *logQueue {
+	logQueuesMu.Lock()
+	defer logQueuesMu.Unlock()
+	q, ok := logQueues[name]
+	if !ok {
+		q = &logQueue{name: name}
+		logQueues[name] = q
+	}
+	return q
+}
+
+// forwardLogs sends log records to the configured log outputs in the background, it is also
+// called without records so the records queued after a failed push are retried
+func forwardLogs(outputs config.Outputs, records []tools.LogRecord) {
+	if outputs.Loki.Url != "" {
+		logQueueOf("loki").send(records, func(batch []tools.LogRecord) bool {
+			return tools.LokiPush(batch, outputs.Loki.Url, outputs.Loki.Labels)
+		})
+	}
+	if outputs.Syslog.Address != "" {
+		logQueueOf("syslog").send(records, func(batch []tools.LogRecord) bool {
+			return tools.SyslogWrite(batch, outputs.Syslog.Network, outputs.Syslog.Address)
+		})
+	}
+}
+
+// send queues the records and pushes the whole queue unless a push is still running
+func (q *logQueue) send(records []tools.LogRecord, push func([]tools.LogRecord) bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.records = append(q.records, records...)
+	q.trim()
+	if q.sending || len(q.records) == 0 {
+		return
+	}
+
+	batch := q.records
+	q.records = nil
+	q.sending = true
+	go func() {
+		ok := push(batch)
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.sending = false
+		if !ok {
+			q.records = append(batch, q.records...)
+			q.trim()
+		}
+	}()
+}
+
+func (q *logQueue) trim() {
+	if n := len(q.records) - maxQueuedLogs; n > 0 {
+		log.Printf("%s is unreachable, dropping the %d oldest queued log records", q.name, n)
+		q.records = append([]tools.LogRecord(nil), q.records[n:]...)
+	}
+}
+
+func (client *Client) selRecords(entries []SelEntry) []tools.LogRecord {
+	records := make([]tools.LogRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, tools.LogRecord{
+			Source:     "sel",
+			Host:       client.host,
+			DeviceName: client.getDeviceName(),
+			Severity:   selSeverity(e.Severity),
+			Component:  e.Component,
+			Id:         e.Id,
+			Message:    e.Message,
+			Time:       e.Created,
+		})
+	}
+	return records
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"server_exporter/tools"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLogQueueRetriesFailedPush(t *testing.T) {
+	q := &logQueue{name: "test"}
+	pushed := make(chan []tools.LogRecord)
+	push := func(ok bool) func([]tools.LogRecord) bool {
+		return func(batch []tools.LogRecord) bool {
+			pushed <- batch
+			return ok
+		}
+	}
+	wait := func() []tools.LogRecord {
+		select {
+		case batch := <-pushed:
+			// let the push goroutine requeue the batch
+			for i := 0; i < 100; i++ {
+				q.mu.Lock()
+				sending := q.sending
+				q.mu.Unlock()
+				if !sending {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			return batch
+		case <-time.After(time.Second):
+			t.Fatal("no push")
+			return nil
+		}
+	}
+
+	q.send([]tools.LogRecord{{Id: "1"}}, push(false))
+	if batch := wait(); len(batch) != 1 {
+		t.Fatalf("first push = %v, want 1 record", batch)
+	}
+	q.send([]tools.LogRecord{{Id: "2"}}, push(true))
+	if batch := wait(); len(batch) != 2 || batch[0].Id != "1" || batch[1].Id != "2" {
+		t.Fatalf("push after a failure = %v, want the failed record before the new one", batch)
+	}
+	q.send(nil, push(true))
+	select {
+	case batch := <-pushed:
+		t.Errorf("pushed %v with nothing queued", batch)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestLogQueueDropsOldest(t *testing.T) {
+	q := &logQueue{name: "test", sending: true}
+	records := make([]tools.LogRecord, maxQueuedLogs+5)
+	for i := range records {
+		records[i].Id = strconv.Itoa(i)
+	}
+	q.send(records, func([]tools.LogRecord) bool { return true })
+	if len(q.records) != maxQueuedLogs || q.records[0].Id != "5" {
+		t.Errorf("queue holds %d records starting with %q, want %d starting with %q",
+			len(q.records), q.records[0].Id, maxQueuedLogs, "5")
+	}
+}
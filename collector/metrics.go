@@ -63,17 +63,77 @@ func (collector *Collector) NewSystemMachineInfo(manufacturer, model, serial, sk
 	return "idrac_system_machine_info{ip=\"" + collector.client.host + "\", manufacturer=\"" + manufacturer + "\", model=\"" + model + "\", serial=\"" + serial + "\", sku=\"" + sku + "\"} 1"
 }
 
-func (collector *Collector) NewSensorsTemperature(temperature float64, id, name, units string) string {
-	return "idrac_sensors_temperature{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", units=\"" + units + "\"} " + strconv.FormatFloat(temperature, 'f', 0, 64)
+func (collector *Collector) NewSensorsTemperature(temperature float64, id, name, context, units string) string {
+	return "idrac_sensors_temperature{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", units=\"" + units + "\"} " + strconv.FormatFloat(temperature, 'f', 0, 64)
 }
 
-func (collector *Collector) NewSensorsFanHealth(id, name, health string) string {
+func (collector *Collector) NewSensorsTemperatureThreshold(temperature float64, id, name, context, threshold string) string {
+	return "redfish_sensors_temperature_threshold_celsius{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", threshold=\"" + threshold + "\"} " + strconv.FormatFloat(temperature, 'f', -1, 64)
+}
+
+func (collector *Collector) NewSensorsTemperatureReadingRange(temperature float64, id, name, context, bound string) string {
+	return "redfish_sensors_temperature_reading_range_celsius{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", bound=\"" + bound + "\"} " + strconv.FormatFloat(temperature, 'f', -1, 64)
+}
+
+func (collector *Collector) NewSensorsFanHealth(id, name, context, health string) string {
+	value := health2value(health)
+	return "idrac_sensors_fan_health{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewSensorsFanSpeed(speed float64, id, name, context, units string) string {
+	return "idrac_sensors_fan_speed{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", units=\"" + units + "\"} " + strconv.FormatFloat(speed, 'f', 0, 64)
+}
+
+func (collector *Collector) NewSensorsFanThreshold(speed float64, id, name, context, units, threshold string) string {
+	return "redfish_sensors_fan_threshold{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", units=\"" + units + "\", threshold=\"" + threshold + "\"} " + strconv.FormatFloat(speed, 'f', -1, 64)
+}
+
+func (collector *Collector) NewSensorsFanReadingRange(speed float64, id, name, context, units, bound string) string {
+	return "redfish_sensors_fan_reading_range{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", units=\"" + units + "\", bound=\"" + bound + "\"} " + strconv.FormatFloat(speed, 'f', -1, 64)
+}
+
+// NewSensorThresholdState exports the most severe threshold crossed by a sensor as 0 normal, 1 noncritical, 2 critical or 3 fatal
+func (collector *Collector) NewSensorThresholdState(sensor, id, name, context, state string) string {
+	value := float64(thresholdRank[state])
+	return "redfish_sensor_threshold_state{ip=\"" + collector.client.host + "\", sensor=\"" + sensor + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", state=\"" + state + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewSensorsVoltage(volts float64, id, name, context string) string {
+	return "redfish_sensors_voltage_volts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\"} " + strconv.FormatFloat(volts, 'f', -1, 64)
+}
+
+func (collector *Collector) NewSensorsVoltageHealth(id, name, context, health string) string {
+	value := health2value(health)
+	return "redfish_sensors_voltage_health{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewSensorsVoltageThreshold(volts float64, id, name, context, threshold string) string {
+	return "redfish_sensors_voltage_threshold_volts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", context=\"" + context + "\", threshold=\"" + threshold + "\"} " + strconv.FormatFloat(volts, 'f', -1, 64)
+}
+
+func (collector *Collector) NewRedundancyHealth(group, id, name, mode, health string) string {
 	value := health2value(health)
-	return "idrac_sensors_fan_health{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+	return "redfish_redundancy_health{ip=\"" + collector.client.host + "\", group=\"" + group + "\", id=\"" + id + "\", name=\"" + name + "\", mode=\"" + mode + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewRedundancyEnabled(group, id, name string, enabled bool) string {
+	var value float64
+	if enabled {
+		value = 1
+	}
+	return "redfish_redundancy_enabled{ip=\"" + collector.client.host + "\", group=\"" + group + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
 
-func (collector *Collector) NewSensorsFanSpeed(speed float64, id, name, units string) string {
-	return "idrac_sensors_fan_speed{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", units=\"" + units + "\"} " + strconv.FormatFloat(speed, 'f', 0, 64)
+func (collector *Collector) NewRedundancyMembers(group, id, name string, members int) string {
+	return "redfish_redundancy_members{ip=\"" + collector.client.host + "\", group=\"" + group + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.Itoa(members)
+}
+
+func (collector *Collector) NewRedundancyMinNeeded(group, id, name string, min int) string {
+	return "redfish_redundancy_min_needed{ip=\"" + collector.client.host + "\", group=\"" + group + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.Itoa(min)
+}
+
+func (collector *Collector) NewRedundancyMaxSupported(group, id, name string, max int) string {
+	return "redfish_redundancy_max_supported{ip=\"" + collector.client.host + "\", group=\"" + group + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.Itoa(max)
 }
 
 func (collector *Collector) NewPowerSupplyHealth(health, id string) string {
@@ -94,13 +154,29 @@ func (collector *Collector) NewPowerSupplyOutputWatts(value float64, id string)
 }
 
 func (collector *Collector) NewPowerSupplyCapacityWatts(value float64, id string) string {
-	return "idrac_power_supply_capacity_watts{ip=\"" + collector.client.host + ", id=\"" + id + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+	return "idrac_power_supply_capacity_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
 
 func (collector *Collector) NewPowerSupplyEfficiencyPercent(value float64, id string) string {
 	return "idrac_power_supply_efficiency_percent{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
 
+func (collector *Collector) NewPowerSupplyInfo(id string, psu *PowerSupplyUnit) string {
+	return "redfish_power_supply_info{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + psu.Name + "\", manufacturer=\"" + psu.Manufacturer + "\", model=\"" + psu.Model + "\", serial=\"" + psu.SerialNumber + "\", part_number=\"" + psu.PartNumber + "\", spare_part_number=\"" + psu.SparePartNumber + "\", firmware=\"" + psu.FirmwareVersion + "\", type=\"" + psu.PowerSupplyType + "\", line_input_voltage_type=\"" + psu.LineInputVoltageType + "\"} 1"
+}
+
+func (collector *Collector) NewPowerSupplyInputRangeVoltage(value float64, id, index, inputType, bound string) string {
+	return "redfish_power_supply_input_range_voltage{ip=\"" + collector.client.host + "\", id=\"" + id + "\", range=\"" + index + "\", input_type=\"" + inputType + "\", bound=\"" + bound + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (collector *Collector) NewPowerSupplyInputRangeFrequency(value float64, id, index, inputType, bound string) string {
+	return "redfish_power_supply_input_range_frequency_hz{ip=\"" + collector.client.host + "\", id=\"" + id + "\", range=\"" + index + "\", input_type=\"" + inputType + "\", bound=\"" + bound + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (collector *Collector) NewPowerSupplyInputRangeOutputWatts(value float64, id, index, inputType string) string {
+	return "redfish_power_supply_input_range_output_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", range=\"" + index + "\", input_type=\"" + inputType + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
 func (collector *Collector) NewPowerControlConsumedWatts(value float64, id, name string) string {
 	return "idrac_power_control_consumed_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\" , name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
@@ -109,6 +185,34 @@ func (collector *Collector) NewPowerControlCapacityWatts(value float64, id, name
 	return "idrac_power_control_capacity_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\" , name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
 
+func (collector *Collector) NewPowerControlAllocatedWatts(value float64, id, name string) string {
+	return "redfish_power_control_allocated_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (collector *Collector) NewPowerControlAvailableWatts(value float64, id, name string) string {
+	return "redfish_power_control_available_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (collector *Collector) NewPowerControlRequestedWatts(value float64, id, name string) string {
+	return "redfish_power_control_requested_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (collector *Collector) NewPowerControlLimitWatts(value int, id, name, exception string) string {
+	return "redfish_power_control_limit_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", exception=\"" + exception + "\"} " + strconv.Itoa(value)
+}
+
+func (collector *Collector) NewPowerControlLimitCorrection(value int, id, name string) string {
+	return "redfish_power_control_limit_correction_milliseconds{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.Itoa(value)
+}
+
+func (collector *Collector) NewPowerControlCappingActive(active bool, id, name string) string {
+	var value float64
+	if active {
+		value = 1
+	}
+	return "redfish_power_control_capping_active{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
 func (collector *Collector) NewPowerControlMinConsumedWatts(value float64, id, name string) string {
 	return "idrac_power_control_min_consumed_watts{ip=\"" + collector.client.host + "\", id=\"" + id + "\" , name=\"" + name + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
@@ -129,6 +233,51 @@ func (collector *Collector) NewSelEntry(id string, message string, component str
 	return "idrac_sel_entry{ip=\"" + collector.client.host + "\", component=\"" + component + "\", id=\"" + id + "\", message=\"" + message + "\", severity=\"" + severity + "\", time=\"" + created.String() + "\"} " + strconv.Itoa(int(created.Unix()))
 }
 
+func (collector *Collector) NewSelEntries(severity, component string, n int64) string {
+	return "redfish_sel_entries_total{ip=\"" + collector.client.host + "\", severity=\"" + severity + "\", component=\"" + component + "\"} " + strconv.FormatInt(n, 10)
+}
+
+func (collector *Collector) NewSelLastEntryTimestamp(severity string, created time.Time) string {
+	return "redfish_sel_last_entry_timestamp_seconds{ip=\"" + collector.client.host + "\", severity=\"" + severity + "\"} " + strconv.FormatInt(created.Unix(), 10)
+}
+
+func (collector *Collector) NewSelLatestEntry(rank int, e SelEntry) string {
+	return "redfish_sel_latest_entry_info{ip=\"" + collector.client.host + "\", rank=\"" + strconv.Itoa(rank) + "\", id=\"" + labelValue(e.Id) + "\", component=\"" + labelValue(e.Component) + "\", message=\"" + labelValue(e.Message) + "\", severity=\"" + selSeverity(e.Severity) + "\"} " + strconv.FormatInt(e.Created.Unix(), 10)
+}
+
+func (collector *Collector) NewEventsReceived(eventType, severity string, n int64) string {
+	return "redfish_events_received_total{ip=\"" + collector.client.host + "\", type=\"" + eventType + "\", severity=\"" + severity + "\"} " + strconv.FormatInt(n, 10)
+}
+
+func (collector *Collector) NewEventSubscriptionActive(active bool) string {
+	var value float64
+	if active {
+		value = 1
+	}
+	return "redfish_event_subscription_active{ip=\"" + collector.client.host + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewEventStreamConnected(connected bool) string {
+	var value float64
+	if connected {
+		value = 1
+	}
+	return "redfish_event_stream_connected{ip=\"" + collector.client.host + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewEventStreamConnects(n int64) string {
+	return "redfish_event_stream_connects_total{ip=\"" + collector.client.host + "\"} " + strconv.FormatInt(n, 10)
+}
+
+// NewTelemetryMetric carries the timestamp of the report, a zero timestamp leaves it to the scraper
+func (collector *Collector) NewTelemetryMetric(report, id, property string, value float64, ts time.Time) string {
+	metric := "redfish_telemetry_metric_value{ip=\"" + collector.client.host + "\", report=\"" + report + "\", metric=\"" + id + "\", property=\"" + property + "\"} " + strconv.FormatFloat(value, 'f', -1, 64)
+	if !ts.IsZero() {
+		metric += " " + strconv.FormatInt(ts.UnixMilli(), 10)
+	}
+	return metric
+}
+
 func (collector *Collector) NewDriveInfo(id, name, manufacturer, model, serial, mediatype, protocol string, slot int) string {
 	//var slotstr string
 	//
@@ -154,6 +303,33 @@ func (collector *Collector) NewDriveLifeLeft(id string, lifeLeft int) string {
 	return "\nidrac_drive_life_left_percent{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(lifeLeft)
 }
 
+func (collector *Collector) NewStorageControllerInfo(storage, id, name, manufacturer, model, serial, firmware string) string {
+	return "redfish_storage_controller_info{ip=\"" + collector.client.host + "\", storage=\"" + storage + "\", id=\"" + id + "\", name=\"" + name + "\", manufacturer=\"" + manufacturer + "\", model=\"" + model + "\", serial=\"" + serial + "\", firmware=\"" + firmware + "\"} 1"
+}
+
+func (collector *Collector) NewStorageControllerHealth(storage, id, health string) string {
+	value := health2value(health)
+	return "redfish_storage_controller_health{ip=\"" + collector.client.host + "\", storage=\"" + storage + "\", id=\"" + id + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewStorageControllerSpeed(storage, id string, speed float64) string {
+	return "redfish_storage_controller_speed_gbps{ip=\"" + collector.client.host + "\", storage=\"" + storage + "\", id=\"" + id + "\"} " + strconv.FormatFloat(speed, 'f', -1, 64)
+}
+
+func (collector *Collector) NewStorageControllerCacheBytes(storage, id string, sizeMiB int) string {
+	return "redfish_storage_controller_cache_bytes{ip=\"" + collector.client.host + "\", storage=\"" + storage + "\", id=\"" + id + "\"} " + strconv.Itoa(sizeMiB*1024*1024)
+}
+
+func (collector *Collector) NewStorageControllerCacheHealth(storage, id, health string) string {
+	value := health2value(health)
+	return "redfish_storage_controller_cache_health{ip=\"" + collector.client.host + "\", storage=\"" + storage + "\", id=\"" + id + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewStorageBatteryHealth(storage, name, health string) string {
+	value := health2value(health)
+	return "redfish_storage_battery_health{ip=\"" + collector.client.host + "\", storage=\"" + storage + "\", name=\"" + name + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
 func (collector *Collector) NewMemoryModuleInfo(id, name, manufacturer, memtype, serial, ecc string, rank int) string {
 	return "idrac_memory_module_info{ip=\"" + collector.client.host + "\", ecc=\"" + ecc + "\", id=\"" + id + "\", manufacturer=\"" + manufacturer + "\", name=\"" + name + "\", rank=\"" + strconv.Itoa(rank) + "\", serial=\"" + serial + "\", type=\"" + memtype + "\"} 1"
 }
@@ -171,6 +347,107 @@ func (collector *Collector) NewMemoryModuleSpeed(id string, speed int) string {
 	return "idrac_memory_module_speed_mhz{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(speed)
 }
 
+func (collector *Collector) NewProcessorInfo(id, socket, ptype, manufacturer, model, microcode string) string {
+	return "redfish_processor_info{ip=\"" + collector.client.host + "\", id=\"" + id + "\", socket=\"" + socket + "\", type=\"" + ptype + "\", manufacturer=\"" + manufacturer + "\", model=\"" + model + "\", microcode=\"" + microcode + "\"} 1"
+}
+
+func (collector *Collector) NewProcessorHealth(id, ptype, health string) string {
+	value := health2value(health)
+	return "redfish_processor_health{ip=\"" + collector.client.host + "\", id=\"" + id + "\", type=\"" + ptype + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewProcessorState(id, ptype, state string) string {
+	var value float64
+	if state == StateEnabled {
+		value = 1
+	}
+	return "redfish_processor_enabled{ip=\"" + collector.client.host + "\", id=\"" + id + "\", type=\"" + ptype + "\", state=\"" + state + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewProcessorCores(id string, cores int) string {
+	return "redfish_processor_cores{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(cores)
+}
+
+func (collector *Collector) NewProcessorThreads(id string, threads int) string {
+	return "redfish_processor_threads{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(threads)
+}
+
+func (collector *Collector) NewProcessorMaxSpeed(id string, speed int) string {
+	return "redfish_processor_max_speed_mhz{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(speed)
+}
+
+func (collector *Collector) NewProcessorOperatingSpeed(id string, speed int) string {
+	return "redfish_processor_operating_speed_mhz{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(speed)
+}
+
+func (collector *Collector) NewProcessorTemperature(id string, celsius float64) string {
+	return "redfish_processor_temperature_celsius{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(celsius, 'f', -1, 64)
+}
+
+func (collector *Collector) NewProcessorThrottlingCelsius(id string, celsius float64) string {
+	return "redfish_processor_throttling_celsius{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(celsius, 'f', -1, 64)
+}
+
+func (collector *Collector) NewProcessorThrottled(id string, throttled bool) string {
+	var value float64
+	if throttled {
+		value = 1
+	}
+	return "redfish_processor_throttled{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewProcessorCacheErrors(id, kind string, n int64) string {
+	return "redfish_processor_cache_" + kind + "_errors_total{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatInt(n, 10)
+}
+
+func (collector *Collector) NewPcieDeviceInfo(id, name, manufacturer, model, firmware, devtype, slot string) string {
+	return "redfish_pcie_device_info{ip=\"" + collector.client.host + "\", id=\"" + id + "\", name=\"" + name + "\", manufacturer=\"" + manufacturer + "\", model=\"" + model + "\", firmware=\"" + firmware + "\", type=\"" + devtype + "\", slot=\"" + slot + "\"} 1"
+}
+
+func (collector *Collector) NewPcieDeviceHealth(id, health string) string {
+	value := health2value(health)
+	return "redfish_pcie_device_health{ip=\"" + collector.client.host + "\", id=\"" + id + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewPcieDeviceLinkWidth(id string, lanes, maxLanes int) []string {
+	return []string{
+		"redfish_pcie_device_link_width{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(lanes),
+		"redfish_pcie_device_link_max_width{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(maxLanes),
+	}
+}
+
+func (collector *Collector) NewPcieDeviceLinkGeneration(id string, gen, maxGen int) []string {
+	return []string{
+		"redfish_pcie_device_link_generation{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(gen),
+		"redfish_pcie_device_link_max_generation{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.Itoa(maxGen),
+	}
+}
+
+func (collector *Collector) NewPcieDeviceLinkDegraded(id string, degraded bool) string {
+	var value float64
+	if degraded {
+		value = 1
+	}
+	return "redfish_pcie_device_link_degraded{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewPcieFunctionInfo(device, id, class, vendorId, deviceId string) string {
+	return "redfish_pcie_function_info{ip=\"" + collector.client.host + "\", device=\"" + device + "\", id=\"" + id + "\", class=\"" + class + "\", vendor_id=\"" + vendorId + "\", device_id=\"" + deviceId + "\"} 1"
+}
+
+func (collector *Collector) NewPcieFunctionHealth(device, id, health string) string {
+	value := health2value(health)
+	return "redfish_pcie_function_health{ip=\"" + collector.client.host + "\", device=\"" + device + "\", id=\"" + id + "\", status=\"" + health + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func (collector *Collector) NewPcieSlotOccupied(slot, pcieType, slotType string, lanes int, occupied bool) string {
+	var value float64
+	if occupied {
+		value = 1
+	}
+	return "redfish_pcie_slot_occupied{ip=\"" + collector.client.host + "\", slot=\"" + slot + "\", pcie_type=\"" + pcieType + "\", slot_type=\"" + slotType + "\", lanes=\"" + strconv.Itoa(lanes) + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
+}
+
 func (collector *Collector) NewNetworkInterfaceHealth(id, health string) string {
 	value := health2value(health)
 	return "idrac_network_interface_health{ip=\"" + collector.client.host + "\", id=\"" + id + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
@@ -189,3 +466,45 @@ func (collector *Collector) NewNetworkPortLinkUp(iface, id, status string) strin
 	value := linkstatus2value(status)
 	return "idrac_network_port_link_up{ip=\"" + collector.client.host + "\", id=\"" + id + "\", interface=\"" + iface + "\", status=\"" + status + "\"} " + strconv.FormatFloat(value, 'f', 0, 64)
 }
+
+func (collector *Collector) NewDataAge(name string, age float64) string {
+	return "redfish_data_age_seconds{ip=\"" + collector.client.host + "\", collector=\"" + name + "\"} " + strconv.FormatFloat(age, 'f', 0, 64)
+}
+
+func (collector *Collector) NewSchedulerRequests(requests int64) string {
+	return "redfish_scheduler_requests_total{ip=\"" + collector.client.host + "\"} " + strconv.FormatInt(requests, 10)
+}
+
+func (collector *Collector) NewSchedulerInFlight(inFlight int64) string {
+	return "redfish_scheduler_in_flight_requests{ip=\"" + collector.client.host + "\"} " + strconv.FormatInt(inFlight, 10)
+}
+
+func (collector *Collector) NewSchedulerQueueWait(wait float64) string {
+	return "redfish_scheduler_queue_wait_seconds_total{ip=\"" + collector.client.host + "\"} " + strconv.FormatFloat(wait, 'f', 3, 64)
+}
+
+func breakerstate2string(state int) string {
+	switch state {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	}
+	return "closed"
+}
+
+func (collector *Collector) NewBreakerState(state int) string {
+	return "redfish_circuit_breaker_state{ip=\"" + collector.client.host + "\", state=\"" + breakerstate2string(state) + "\"} " + strconv.Itoa(state)
+}
+
+func (collector *Collector) NewBreakerFailures(failures int) string {
+	return "redfish_circuit_breaker_consecutive_failures{ip=\"" + collector.client.host + "\"} " + strconv.Itoa(failures)
+}
+
+func (collector *Collector) NewBreakerTrips(trips int64) string {
+	return "redfish_circuit_breaker_trips_total{ip=\"" + collector.client.host + "\"} " + strconv.FormatInt(trips, 10)
+}
+
+func (collector *Collector) NewRequestRetries(code string, retries int64) string {
+	return "redfish_request_retries_total{ip=\"" + collector.client.host + "\", code=\"" + code + "\"} " + strconv.FormatInt(retries, 10)
+}
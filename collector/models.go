@@ -3,7 +3,7 @@ package collector
 import (
 	"encoding/json"
 	"strconv"
-	"time"
+	"strings"
 )
 
 const (
@@ -27,6 +27,8 @@ type Status struct {
 
 // Redundancy is a common structure used in any entity with redundancy
 type Redundancy struct {
+	OdataId           string  `json:"@odata.id"`
+	MemberId          string  `json:"MemberId"`
 	Name              string  `json:"Name"`
 	MaxNumSupported   int     `json:"MaxNumSupported"`
 	MinNumNeeded      int     `json:"MinNumNeeded"`
@@ -93,6 +95,7 @@ type ChassisResponse struct {
 	} `json:"Location"`
 	Memory           Odata  `json:"Memory"`
 	NetworkAdapters  Odata  `json:"NetworkAdapters"`
+	PCIeDevices      Odata  `json:"PCIeDevices"`
 	PCIeSlots        Odata  `json:"PCIeSlots"`
 	Power            Odata  `json:"Power"`
 	Sensors          Odata  `json:"Sensors"`
@@ -122,8 +125,8 @@ type Fan struct {
 	MaxReadingRange           any          `json:"MaxReadingRange"`
 	MinReadingRange           any          `json:"MinReadingRange"`
 	PhysicalContext           string       `json:"PhysicalContext"`
-	Reading                   float64      `json:"Reading"`
-	CurrentReading            float64      `json:"CurrentReading"`
+	Reading                   any          `json:"Reading"`
+	CurrentReading            any          `json:"CurrentReading"`
 	Units                     string       `json:"Units"`
 	ReadingUnits              string       `json:"ReadingUnits"`
 	Redundancy                []Redundancy `json:"Redundancy"`
@@ -143,11 +146,15 @@ func (f *Fan) GetName() string {
 	return f.Name
 }
 
-func (f *Fan) GetReading() float64 {
-	if f.Reading > 0 {
-		return f.Reading
+// GetReading returns the fan reading, ok is false when the bmc reports neither Reading nor CurrentReading
+func (f *Fan) GetReading() (float64, bool) {
+	if reading, ok := sensorNumber(f.Reading); ok && reading > 0 {
+		return reading, true
 	}
-	return f.CurrentReading
+	if reading, ok := sensorNumber(f.CurrentReading); ok {
+		return reading, true
+	}
+	return sensorNumber(f.Reading)
 }
 
 func (f *Fan) GetUnits() string {
@@ -157,6 +164,12 @@ func (f *Fan) GetUnits() string {
 	return f.Units
 }
 
+// thresholds returns the thresholds the bmc reports, null and unparsable values are left out
+func (f *Fan) thresholds() []sensorThreshold {
+	return sensorThresholds(f.LowerThresholdNonCritical, f.LowerThresholdCritical, f.LowerThresholdFatal,
+		f.UpperThresholdNonCritical, f.UpperThresholdCritical, f.UpperThresholdFatal)
+}
+
 func (f *Fan) GetId(fallback int) string {
 	if len(f.MemberId) > 0 {
 		return f.MemberId
@@ -169,18 +182,24 @@ type Temperature struct {
 	Number                    int     `json:"Number"`
 	MemberId                  string  `json:"MemberId"`
 	ReadingCelsius            float64 `json:"ReadingCelsius"`
-	MaxReadingRangeTemp       float64 `json:"MaxReadingRangeTemp"`
-	MinReadingRangeTemp       float64 `json:"MinReadingRangeTemp"`
+	MaxReadingRangeTemp       any     `json:"MaxReadingRangeTemp"`
+	MinReadingRangeTemp       any     `json:"MinReadingRangeTemp"`
 	PhysicalContext           string  `json:"PhysicalContext"`
-	LowerThresholdCritical    float64 `json:"LowerThresholdCritical"`
-	LowerThresholdFatal       float64 `json:"LowerThresholdFatal"`
-	LowerThresholdNonCritical float64 `json:"LowerThresholdNonCritical"`
-	UpperThresholdCritical    float64 `json:"UpperThresholdCritical"`
-	UpperThresholdFatal       float64 `json:"UpperThresholdFatal"`
-	UpperThresholdNonCritical float64 `json:"UpperThresholdNonCritical"`
+	LowerThresholdCritical    any     `json:"LowerThresholdCritical"`
+	LowerThresholdFatal       any     `json:"LowerThresholdFatal"`
+	LowerThresholdNonCritical any     `json:"LowerThresholdNonCritical"`
+	UpperThresholdCritical    any     `json:"UpperThresholdCritical"`
+	UpperThresholdFatal       any     `json:"UpperThresholdFatal"`
+	UpperThresholdNonCritical any     `json:"UpperThresholdNonCritical"`
 	Status                    Status  `json:"Status"`
 }
 
+// thresholds returns the thresholds the bmc reports, null and unparsable values are left out
+func (t *Temperature) thresholds() []sensorThreshold {
+	return sensorThresholds(t.LowerThresholdNonCritical, t.LowerThresholdCritical, t.LowerThresholdFatal,
+		t.UpperThresholdNonCritical, t.UpperThresholdCritical, t.UpperThresholdFatal)
+}
+
 func (t *Temperature) GetId(fallback int) string {
 	if len(t.MemberId) > 0 {
 		return t.MemberId
@@ -192,19 +211,52 @@ func (t *Temperature) GetId(fallback int) string {
 }
 
 type StorageController struct {
-	Id                 string  `json:"Id"`
-	Name               string  `json:"Name"`
-	Description        string  `json:"Description"`
-	Drives             []Odata `json:"Drives"`
-	Status             Status  `json:"Status"`
-	StorageControllers []struct {
-		FirmwareVersion string  `json:"FirmwareVersion"`
-		Manufacturer    string  `json:"Manufacturer"`
-		Model           string  `json:"Model"`
-		Name            string  `json:"Name"`
-		SpeedGbps       float64 `json:"SpeedGbps"`
-		Status          Status  `json:"Status"`
-	}
+	OdataId            string                  `json:"@odata.id"`
+	Id                 string                  `json:"Id"`
+	Name               string                  `json:"Name"`
+	Description        string                  `json:"Description"`
+	Drives             []Odata                 `json:"Drives"`
+	Status             Status                  `json:"Status"`
+	StorageControllers []StorageControllerUnit `json:"StorageControllers"`
+	Oem                struct {
+		Dell *struct {
+			DellControllerBattery *struct {
+				Name          string `json:"Name"`
+				PrimaryStatus string `json:"PrimaryStatus"`
+				RAIDState     string `json:"RAIDState"`
+			} `json:"DellControllerBattery"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+	// HPE SmartStorage array controllers describe the controller itself instead of listing StorageControllers
+	Model           string `json:"Model"`
+	SerialNumber    string `json:"SerialNumber"`
+	FirmwareVersion *struct {
+		Current struct {
+			VersionString string `json:"VersionString"`
+		} `json:"Current"`
+	} `json:"FirmwareVersion"`
+	CacheMemorySizeMiB      int    `json:"CacheMemorySizeMiB"`
+	CacheModuleStatus       any    `json:"CacheModuleStatus"`
+	BackupPowerSourceStatus string `json:"BackupPowerSourceStatus"`
+}
+
+type StorageControllerUnit struct {
+	MemberId        string        `json:"MemberId"`
+	FirmwareVersion string        `json:"FirmwareVersion"`
+	Manufacturer    string        `json:"Manufacturer"`
+	Model           string        `json:"Model"`
+	Name            string        `json:"Name"`
+	SerialNumber    string        `json:"SerialNumber"`
+	PartNumber      string        `json:"PartNumber"`
+	SpeedGbps       float64       `json:"SpeedGbps"`
+	Status          Status        `json:"Status"`
+	CacheSummary    *CacheSummary `json:"CacheSummary"`
+}
+
+type CacheSummary struct {
+	TotalCacheSizeMiB      int    `json:"TotalCacheSizeMiB"`
+	PersistentCacheSizeMiB int    `json:"PersistentCacheSizeMiB"`
+	Status                 Status `json:"Status"`
 }
 
 type Drive struct {
@@ -233,6 +285,21 @@ type Drive struct {
 	CapacityMiB                       int    `json:"CapacityMiB"`
 	InterfaceType                     string `json:"InterfaceType"`
 	SSDEnduranceUtilizationPercentage int    `json:"SSDEnduranceUtilizationPercentage"`
+	Oem                               struct {
+		Lenovo    *OemDrive `json:"Lenovo"`
+		TsFujitsu *OemDrive `json:"ts_fujitsu"`
+	} `json:"Oem"`
+}
+
+// OemDrive holds the drive fields XCC and iRMC report under Oem when the standard ones are empty
+type OemDrive struct {
+	Manufacturer    string `json:"Manufacturer"`
+	Model           string `json:"Model"`
+	ProductName     string `json:"ProductName"`
+	SerialNumber    string `json:"SerialNumber"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	CapacityBytes   int    `json:"CapacityBytes"`
+	DriveStatus     string `json:"DriveStatus"`
 }
 
 func (d *Drive) GetSlot() int {
@@ -250,6 +317,7 @@ type Memory struct {
 	Name              string `json:"Name"`
 	Description       string `json:"Description"`
 	Manufacturer      string `json:"Manufacturer"`
+	FirmwareRevision  string `json:"FirmwareRevision"`
 	ErrorCorrection   string `json:"ErrorCorrection"`
 	MemoryDeviceType  string `json:"MemoryDeviceType"`
 	AllowedSpeedsMHz  []int  `json:"AllowedSpeedsMHz"`
@@ -278,6 +346,23 @@ type NetworkInterface struct {
 	Status       Status `json:"Status"`
 	NetworkPorts Odata  `json:"NetworkPorts"`
 	Ports        Odata  `json:"Ports"`
+	Links        struct {
+		NetworkAdapter Odata `json:"NetworkAdapter"`
+	} `json:"Links"`
+}
+
+type NetworkAdapter struct {
+	Id           string `json:"Id"`
+	Name         string `json:"Name"`
+	Manufacturer string `json:"Manufacturer"`
+	Model        string `json:"Model"`
+	SerialNumber string `json:"SerialNumber"`
+	PartNumber   string `json:"PartNumber"`
+	SKU          string `json:"SKU"`
+	Status       Status `json:"Status"`
+	Controllers  []struct {
+		FirmwarePackageVersion string `json:"FirmwarePackageVersion"`
+	} `json:"Controllers"`
 }
 
 func (n *NetworkInterface) GetPorts() string {
@@ -289,14 +374,15 @@ func (n *NetworkInterface) GetPorts() string {
 }
 
 type NetworkPort struct {
-	Id                        string `json:"Id"`
-	Name                      string `json:"Name"`
-	Description               string `json:"Description"`
-	LinkStatus                string `json:"LinkStatus"`
-	CurrentLinkSpeedMbps      int    `json:"CurrentLinkSpeedMbps"`
-	CurrentSpeedGbps          int    `json:"CurrentSpeedGbps"`
-	Status                    Status `json:"Status"`
-	SupportedLinkCapabilities []struct {
+	Id                         string   `json:"Id"`
+	Name                       string   `json:"Name"`
+	Description                string   `json:"Description"`
+	LinkStatus                 string   `json:"LinkStatus"`
+	AssociatedNetworkAddresses []string `json:"AssociatedNetworkAddresses"`
+	CurrentLinkSpeedMbps       int      `json:"CurrentLinkSpeedMbps"`
+	CurrentSpeedGbps           int      `json:"CurrentSpeedGbps"`
+	Status                     Status   `json:"Status"`
+	SupportedLinkCapabilities  []struct {
 		LinkNetworkTechnology string `json:"LinkNetworkTechnology"`
 		LinkSpeedMbps         int    `json:"LinkSpeedMbps"`
 	} `json:"SupportedLinkCapabilities"`
@@ -344,6 +430,7 @@ type SystemResponse struct {
 		TimeoutAction   string `json:"TimeoutAction"`
 	} `json:"HostWatchdogTimer"`
 	HostingRoles  []any `json:"HostingRoles"`
+	LogServices   Odata `json:"LogServices"`
 	Memory        Odata `json:"Memory"`
 	MemorySummary *struct {
 		MemoryMirroring      string  `json:"MemoryMirroring"`
@@ -365,6 +452,7 @@ type SystemResponse struct {
 	SKU            string `json:"SKU"`
 	SecureBoot     Odata  `json:"SecureBoot"`
 	SerialNumber   string `json:"SerialNumber"`
+	UUID           string `json:"UUID"`
 	SimpleStorage  Odata  `json:"SimpleStorage"`
 	Status         Status `json:"Status"`
 	Storage        Odata  `json:"Storage"`
@@ -382,29 +470,48 @@ type PowerResponse struct {
 	PowerControl  []PowerControlUnit `json:"PowerControl"`
 	PowerSupplies []PowerSupplyUnit  `json:"PowerSupplies"`
 	Redundancy    []Redundancy       `json:"Redundancy"`
-	Voltages      []struct {
-		Name                      string `json:"Name"`
-		SensorNumber              int    `json:"SensorNumber"`
-		PhysicalContext           string `json:"PhysicalContext"`
-		Status                    Status `json:"Status"`
-		ReadingVolts              any    `json:"ReadingVolts"`
-		LowerThresholdCritical    any    `json:"LowerThresholdCritical"`
-		LowerThresholdFatal       any    `json:"LowerThresholdFatal"`
-		LowerThresholdNonCritical any    `json:"LowerThresholdNonCritical"`
-		UpperThresholdCritical    any    `json:"UpperThresholdCritical"`
-		UpperThresholdFatal       any    `json:"UpperThresholdFatal"`
-		UpperThresholdNonCritical any    `json:"UpperThresholdNonCritical"`
-	} `json:"Voltages"`
+	Voltages      []Voltage          `json:"Voltages"`
+}
+
+type Voltage struct {
+	Name                      string `json:"Name"`
+	MemberId                  string `json:"MemberId"`
+	SensorNumber              int    `json:"SensorNumber"`
+	PhysicalContext           string `json:"PhysicalContext"`
+	Status                    Status `json:"Status"`
+	ReadingVolts              any    `json:"ReadingVolts"`
+	LowerThresholdCritical    any    `json:"LowerThresholdCritical"`
+	LowerThresholdFatal       any    `json:"LowerThresholdFatal"`
+	LowerThresholdNonCritical any    `json:"LowerThresholdNonCritical"`
+	UpperThresholdCritical    any    `json:"UpperThresholdCritical"`
+	UpperThresholdFatal       any    `json:"UpperThresholdFatal"`
+	UpperThresholdNonCritical any    `json:"UpperThresholdNonCritical"`
+}
+
+func (v *Voltage) GetId(fallback int) string {
+	if len(v.MemberId) > 0 {
+		return v.MemberId
+	}
+	if v.SensorNumber > 0 {
+		return strconv.Itoa(v.SensorNumber)
+	}
+	return strconv.Itoa(fallback)
+}
+
+// thresholds returns the thresholds the bmc reports, null and unparsable values are left out
+func (v *Voltage) thresholds() []sensorThreshold {
+	return sensorThresholds(v.LowerThresholdNonCritical, v.LowerThresholdCritical, v.LowerThresholdFatal,
+		v.UpperThresholdNonCritical, v.UpperThresholdCritical, v.UpperThresholdFatal)
 }
 
 type PowerControlUnit struct {
 	Name                string  `json:"Name"`
 	Id                  string  `json:"Id"`
-	PowerAllocatedWatts float64 `json:"PowerAllocatedWatts"`
-	PowerAvailableWatts float64 `json:"PowerAvailableWatts"`
+	PowerAllocatedWatts any     `json:"PowerAllocatedWatts"`
+	PowerAvailableWatts any     `json:"PowerAvailableWatts"`
 	PowerCapacityWatts  float64 `json:"PowerCapacityWatts"`
 	PowerConsumedWatts  float64 `json:"PowerConsumedWatts"`
-	PowerRequestedWatts float64 `json:"PowerRequestedWatts"`
+	PowerRequestedWatts any     `json:"PowerRequestedWatts"`
 	PowerLimit          *struct {
 		CorrectionInMs int    `json:"CorrectionInMs"`
 		LimitException string `json:"LimitException"`
@@ -420,6 +527,7 @@ type PowerControlUnit struct {
 
 type PowerSupplyUnit struct {
 	Name            string `json:"Name"`
+	MemberId        string `json:"MemberId"`
 	Assembly        Odata  `json:"Assembly"`
 	FirmwareVersion string `json:"FirmwareVersion"`
 	InputRanges     []struct {
@@ -446,6 +554,32 @@ type PowerSupplyUnit struct {
 	SparePartNumber      string       `json:"SparePartNumber"`
 	Status               Status       `json:"Status"`
 	Redundancy           []Redundancy `json:"Redundancy"`
+	Oem                  struct {
+		Lenovo    *OemPowerSupply `json:"Lenovo"`
+		TsFujitsu *OemPowerSupply `json:"ts_fujitsu"`
+	} `json:"Oem"`
+}
+
+// OemPowerSupply holds the power supply fields XCC and iRMC report under Oem when the standard ones are empty
+type OemPowerSupply struct {
+	Manufacturer    string  `json:"Manufacturer"`
+	Model           string  `json:"Model"`
+	SerialNumber    string  `json:"SerialNumber"`
+	PartNumber      string  `json:"PartNumber"`
+	FRUNumber       string  `json:"FRUNumber"`
+	FirmwareVersion string  `json:"FirmwareVersion"`
+	PowerCapacity   float64 `json:"PowerCapacity"`
+}
+
+// GetId identifies the power supply by MemberId or Name, the index is only a last resort as it shifts when a unit is pulled
+func (psu *PowerSupplyUnit) GetId(fallback int) string {
+	if len(psu.MemberId) > 0 {
+		return psu.MemberId
+	}
+	if len(psu.Name) > 0 {
+		return psu.Name
+	}
+	return strconv.Itoa(fallback)
 }
 
 func (psu *PowerSupplyUnit) GetOutputPower() float64 {
@@ -455,58 +589,238 @@ func (psu *PowerSupplyUnit) GetOutputPower() float64 {
 	return psu.LastPowerOutputWatts
 }
 
-type IdracSelResponse struct {
-	Name        string `json:"Name,omitempty"`
-	Description string `json:"Description,omitempty"`
-	Members     []struct {
-		Id           string    `json:"Id"`
-		Name         string    `json:"Name"`
-		EntryType    string    `json:"EntryType"`
-		Severity     string    `json:"Severity"`
-		Created      time.Time `json:"Created"`
-		SensorNumber int       `json:"SensorNumber,omitempty"`
-		MessageArgs  []any     `json:"MessageArgs,omitempty"`
+type Processor struct {
+	Id                    string `json:"Id"`
+	Name                  string `json:"Name"`
+	Socket                string `json:"Socket"`
+	Manufacturer          string `json:"Manufacturer"`
+	Model                 string `json:"Model"`
+	ProcessorType         string `json:"ProcessorType"`
+	ProcessorArchitecture string `json:"ProcessorArchitecture"`
+	InstructionSet        string `json:"InstructionSet"`
+	SerialNumber          string `json:"SerialNumber"`
+	PartNumber            string `json:"PartNumber"`
+	TotalCores            int    `json:"TotalCores"`
+	TotalThreads          int    `json:"TotalThreads"`
+	MaxSpeedMHz           int    `json:"MaxSpeedMHz"`
+	OperatingSpeedMHz     int    `json:"OperatingSpeedMHz"`
+	ProcessorId           *struct {
+		VendorId                string `json:"VendorId"`
+		IdentificationRegisters string `json:"IdentificationRegisters"`
+		EffectiveFamily         string `json:"EffectiveFamily"`
+		EffectiveModel          string `json:"EffectiveModel"`
+		Step                    string `json:"Step"`
+		MicrocodeInfo           string `json:"MicrocodeInfo"`
+	} `json:"ProcessorId"`
+	Status  Status `json:"Status"`
+	Metrics Odata  `json:"Metrics"`
+}
+
+type PartLocation struct {
+	ServiceLabel         string `json:"ServiceLabel"`
+	LocationOrdinalValue *int   `json:"LocationOrdinalValue"`
+}
+
+type PCIeDevice struct {
+	Id              string `json:"Id"`
+	Name            string `json:"Name"`
+	Manufacturer    string `json:"Manufacturer"`
+	Model           string `json:"Model"`
+	SerialNumber    string `json:"SerialNumber"`
+	PartNumber      string `json:"PartNumber"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	DeviceType      string `json:"DeviceType"`
+	Status          Status `json:"Status"`
+	PCIeInterface   *struct {
+		PCIeType    string `json:"PCIeType"`
+		MaxPCIeType string `json:"MaxPCIeType"`
+		LanesInUse  int    `json:"LanesInUse"`
+		MaxLanes    int    `json:"MaxLanes"`
+	} `json:"PCIeInterface"`
+	Slot *struct {
+		Location *struct {
+			PartLocation *PartLocation `json:"PartLocation"`
+		} `json:"Location"`
+	} `json:"Slot"`
+	PCIeFunctions Odata `json:"PCIeFunctions"`
+}
+
+// GetSlot names the slot the device sits in
+func (d *PCIeDevice) GetSlot() string {
+	if d.Slot != nil && d.Slot.Location != nil && d.Slot.Location.PartLocation != nil {
+		return d.Slot.Location.PartLocation.ServiceLabel
+	}
+	return ""
+}
+
+type PCIeFunction struct {
+	Id          string `json:"Id"`
+	Name        string `json:"Name"`
+	DeviceClass string `json:"DeviceClass"`
+	VendorId    string `json:"VendorId"`
+	DeviceId    string `json:"DeviceId"`
+	Status      Status `json:"Status"`
+	Links       struct {
+		PCIeDevice Odata `json:"PCIeDevice"`
+	} `json:"Links"`
+}
 
-		Description string  `json:"Description,omitempty"`
-		EntryCode   xstring `json:"EntryCode,omitempty"`
-		Message     string  `json:"Message,omitempty"`
-		MessageId   string  `json:"MessageId,omitempty"`
-		SensorType  xstring `json:"SensorType,omitempty"`
-	} `json:"Members"`
+type PCIeSlotsResponse struct {
+	Slots []PCIeSlot `json:"Slots"`
 }
 
-type InspurSelResponse struct {
-	OdataType    string `json:"@odata.type"`
+type PCIeSlot struct {
+	PCIeType string `json:"PCIeType"`
+	Lanes    int    `json:"Lanes"`
+	SlotType string `json:"SlotType"`
+	Location *struct {
+		PartLocation *PartLocation `json:"PartLocation"`
+	} `json:"Location"`
+	Status Status `json:"Status"`
+	Links  struct {
+		PCIeDevice []Odata `json:"PCIeDevice"`
+	} `json:"Links"`
+}
+
+// GetLabel names the slot by its service label and else by its position in the list
+func (s *PCIeSlot) GetLabel(i int) string {
+	if s.Location != nil && s.Location.PartLocation != nil && s.Location.PartLocation.ServiceLabel != "" {
+		return s.Location.PartLocation.ServiceLabel
+	}
+	return strconv.Itoa(i)
+}
+
+// ProcessorMetrics leaves the values a bmc does not report nil
+type ProcessorMetrics struct {
+	TemperatureCelsius *float64 `json:"TemperatureCelsius"`
+	ThrottlingCelsius  *float64 `json:"ThrottlingCelsius"`
+	Throttled          *bool    `json:"Throttled"`
+	CacheMetricsTotal  *struct {
+		LifeTime *struct {
+			CorrectableECCErrorCount   *int64 `json:"CorrectableECCErrorCount"`
+			UncorrectableECCErrorCount *int64 `json:"UncorrectableECCErrorCount"`
+		} `json:"LifeTime"`
+	} `json:"CacheMetricsTotal"`
+}
+
+type EventServiceResponse struct {
+	ServiceEnabled            bool     `json:"ServiceEnabled"`
+	Subscriptions             Odata    `json:"Subscriptions"`
+	ServerSentEventUri        string   `json:"ServerSentEventUri"`
+	EventTypesForSubscription []string `json:"EventTypesForSubscription"`
+}
+
+type EventDestination struct {
+	Id          string   `json:"Id,omitempty"`
+	Destination string   `json:"Destination"`
+	Context     string   `json:"Context"`
+	Protocol    string   `json:"Protocol"`
+	EventTypes  []string `json:"EventTypes,omitempty"`
+}
+
+// EventPayload is the body a bmc pushes to an EventDestination
+type EventPayload struct {
+	Id      string        `json:"Id"`
+	Name    string        `json:"Name"`
+	Context string        `json:"Context"`
+	Events  []EventRecord `json:"Events"`
+}
+
+type EventRecord struct {
+	EventType         string `json:"EventType"`
+	EventId           string `json:"EventId"`
+	EventTimestamp    string `json:"EventTimestamp"`
+	Severity          string `json:"Severity"`
+	MessageSeverity   string `json:"MessageSeverity"`
+	Message           string `json:"Message"`
+	MessageId         string `json:"MessageId"`
+	OriginOfCondition Odata  `json:"OriginOfCondition"`
+}
+
+type MetricReportDefinition struct {
+	Id                            string `json:"Id"`
+	Name                          string `json:"Name"`
+	MetricReportDefinitionEnabled *bool  `json:"MetricReportDefinitionEnabled"`
+	Status                        Status `json:"Status"`
+	MetricReport                  Odata  `json:"MetricReport"`
+}
+
+// Enabled treats a definition without the enabled flag as enabled unless its state says otherwise
+func (d *MetricReportDefinition) Enabled() bool {
+	if d.MetricReportDefinitionEnabled != nil {
+		return *d.MetricReportDefinitionEnabled
+	}
+	return d.Status.State != "Disabled"
+}
+
+type TelemetryServiceResponse struct {
+	MetricReportDefinitions Odata `json:"MetricReportDefinitions"`
+	MetricReports           Odata `json:"MetricReports"`
+}
+
+type MetricReport struct {
+	OdataType    string        `json:"@odata.type"`
+	Id           string        `json:"Id"`
+	Name         string        `json:"Name"`
+	Timestamp    string        `json:"Timestamp"`
+	MetricValues []MetricValue `json:"MetricValues"`
+}
+
+type MetricValue struct {
+	MetricId       string          `json:"MetricId"`
+	MetricProperty string          `json:"MetricProperty"`
+	MetricValue    json.RawMessage `json:"MetricValue"`
+	Timestamp      string          `json:"Timestamp"`
+}
+
+// GetValue reads the value, the schema makes it a string but some bmcs send a number
+func (m *MetricValue) GetValue() (float64, bool) {
+	var x interface{}
+	if err := json.Unmarshal(m.MetricValue, &x); err != nil {
+		return 0, false
+	}
+	switch v := x.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+type ManagerResponse struct {
+	Id          string `json:"Id"`
+	Name        string `json:"Name"`
+	ManagerType string `json:"ManagerType"`
+	LogServices Odata  `json:"LogServices"`
+}
+
+type LogService struct {
+	Id           string `json:"Id"`
 	Name         string `json:"Name"`
-	Description  string `json:"Description"`
-	MembersCount int    `json:"Members@odata.count"`
-	OdataContext string `json:"@odata.context"`
-	OdataID      string `json:"@odata.id"`
-	Members      []struct {
-		OdataID         string        `json:"@odata.id"`
-		OdataType       string        `json:"@odata.type"`
-		ID              string        `json:"Id"`
-		Name            string        `json:"Name"`
-		EntryType       string        `json:"EntryType"`
-		Severity        string        `json:"Severity"`
-		Created         string        `json:"Created"`
-		EntryCode       string        `json:"EntryCode"`
-		SensorType      string        `json:"SensorType"`
-		SensorNumber    string        `json:"SensorNumber"`
-		Message         string        `json:"Message"`
-		Links           LogEntryLinks `json:"Links"`
-		Oem             interface{}   `json:"Oem"`
-		OEMRecordFormat string        `json:"OEMRecordFormat"`
-	} `json:"Members"`
-}
-
-type LogEntryLinks struct {
-	OriginOfCondition LinkOrigin  `json:"OriginOfCondition"`
-	Oem               interface{} `json:"Oem"`
-}
-
-type LinkOrigin struct {
-	OdataID string `json:"@odata.id"`
+	LogEntryType string `json:"LogEntryType"`
+	Entries      Odata  `json:"Entries"`
+	Status       Status `json:"Status"`
+}
+
+// LogEntryCollection is the standard redfish collection of log entries
+type LogEntryCollection struct {
+	Name     string     `json:"Name"`
+	Members  []LogEntry `json:"Members"`
+	NextLink string     `json:"Members@odata.nextLink"`
+}
+
+type LogEntry struct {
+	Id         string  `json:"Id"`
+	Name       string  `json:"Name"`
+	EntryType  string  `json:"EntryType"`
+	Severity   string  `json:"Severity"`
+	Created    string  `json:"Created"`
+	Message    string  `json:"Message"`
+	MessageId  string  `json:"MessageId"`
+	EntryCode  xstring `json:"EntryCode"`
+	SensorType xstring `json:"SensorType"`
 }
 
 type xstring string
@@ -0,0 +1,134 @@
+package collector
+
+import (
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// pcieGeneration turns a PCIeType like Gen3 into 3, 0 when unknown
+func pcieGeneration(pcieType string) int {
+	gen, err := strconv.Atoi(strings.TrimPrefix(pcieType, "Gen"))
+	if err != nil {
+		return 0
+	}
+	return gen
+}
+
+// pcieDevicePaths takes the devices linked from the system and else the collection of the chassis
+func (client *Client) pcieDevicePaths() ([]Odata, error) {
+	if len(client.pcieDevices) > 0 || client.pcieDevicesPath == "" {
+		return client.pcieDevices, nil
+	}
+	var group GroupResponse
+	err := client.redfishGet(client.pcieDevicesPath, &group)
+	if err != nil {
+		return nil, err
+	}
+	return group.Members, nil
+}
+
+func (client *Client) RefreshPcie(mc *Collector, ch chan string) error {
+	devices, err := client.pcieDevicePaths()
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var linked int32
+	for _, c := range devices {
+		wg.Add(1)
+		go func(c Odata) {
+			defer wg.Done()
+			var d PCIeDevice
+			if err := client.redfishGet(c.OdataId, &d); err != nil {
+				log.Println(err)
+				return
+			}
+			if d.Status.State == StateAbsent {
+				return
+			}
+			client.refreshPcieDevice(mc, ch, &d)
+			if d.PCIeFunctions.OdataId != "" {
+				atomic.StoreInt32(&linked, 1)
+				client.refreshPcieFunctions(mc, ch, d.Id, d.PCIeFunctions.OdataId)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	// older schemas list the functions only on the system
+	if atomic.LoadInt32(&linked) == 0 {
+		for _, c := range client.pcieFunctions {
+			var f PCIeFunction
+			if err := client.redfishGet(c.OdataId, &f); err != nil {
+				log.Println(err)
+				continue
+			}
+			device := ""
+			if f.Links.PCIeDevice.OdataId != "" {
+				device = path.Base(f.Links.PCIeDevice.OdataId)
+			}
+			ch <- mc.NewPcieFunctionInfo(device, f.Id, f.DeviceClass, f.VendorId, f.DeviceId)
+			ch <- mc.NewPcieFunctionHealth(device, f.Id, client.profile.Health(f.Status))
+		}
+	}
+
+	if client.pcieSlotsPath == "" {
+		return nil
+	}
+	var slots PCIeSlotsResponse
+	err = client.redfishGet(client.pcieSlotsPath, &slots)
+	if err != nil {
+		return err
+	}
+	for i := range slots.Slots {
+		s := &slots.Slots[i]
+		occupied := len(s.Links.PCIeDevice) > 0 || (s.Status.State != "" && s.Status.State != StateAbsent)
+		ch <- mc.NewPcieSlotOccupied(s.GetLabel(i), s.PCIeType, s.SlotType, s.Lanes, occupied)
+	}
+	return nil
+}
+
+func (client *Client) refreshPcieDevice(mc *Collector, ch chan string, d *PCIeDevice) {
+	ch <- mc.NewPcieDeviceInfo(d.Id, d.Name, d.Manufacturer, d.Model, d.FirmwareVersion, d.DeviceType, d.GetSlot())
+	ch <- mc.NewPcieDeviceHealth(d.Id, client.profile.Health(d.Status))
+
+	if link := d.PCIeInterface; link != nil {
+		degraded := false
+		if link.MaxLanes > 0 {
+			for _, m := range mc.NewPcieDeviceLinkWidth(d.Id, link.LanesInUse, link.MaxLanes) {
+				ch <- m
+			}
+			degraded = link.LanesInUse > 0 && link.LanesInUse < link.MaxLanes
+		}
+		gen, maxGen := pcieGeneration(link.PCIeType), pcieGeneration(link.MaxPCIeType)
+		if maxGen > 0 {
+			for _, m := range mc.NewPcieDeviceLinkGeneration(d.Id, gen, maxGen) {
+				ch <- m
+			}
+			degraded = degraded || (gen > 0 && gen < maxGen)
+		}
+		ch <- mc.NewPcieDeviceLinkDegraded(d.Id, degraded)
+	}
+}
+
+func (client *Client) refreshPcieFunctions(mc *Collector, ch chan string, device, functionsPath string) {
+	var group GroupResponse
+	if err := client.redfishGet(functionsPath, &group); err != nil {
+		log.Println(err)
+		return
+	}
+	for _, c := range group.Members {
+		var f PCIeFunction
+		if err := client.redfishGet(c.OdataId, &f); err != nil {
+			log.Println(err)
+			continue
+		}
+		ch <- mc.NewPcieFunctionInfo(device, f.Id, f.DeviceClass, f.VendorId, f.DeviceId)
+		ch <- mc.NewPcieFunctionHealth(device, f.Id, client.profile.Health(f.Status))
+	}
+}
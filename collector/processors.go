@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"log"
+	"sync"
+)
+
+func (client *Client) RefreshProcessors(mc *Collector, ch chan string) error {
+	var group GroupResponse
+	err := client.redfishGet(client.processorsPath, &group)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range group.Members {
+		wg.Add(1)
+		go func(c Odata) {
+			defer wg.Done()
+			var p Processor
+			if err := client.redfishGet(c.OdataId, &p); err != nil {
+				log.Println(err)
+				return
+			}
+			if p.Status.State == StateAbsent {
+				return
+			}
+
+			microcode := ""
+			if p.ProcessorId != nil {
+				microcode = p.ProcessorId.MicrocodeInfo
+			}
+			ch <- mc.NewProcessorInfo(p.Id, p.Socket, p.ProcessorType, p.Manufacturer, p.Model, microcode)
+			ch <- mc.NewProcessorHealth(p.Id, p.ProcessorType, client.profile.Health(p.Status))
+			ch <- mc.NewProcessorState(p.Id, p.ProcessorType, p.Status.State)
+			// accelerators usually report no cores, threads or speeds
+			if p.TotalCores > 0 {
+				ch <- mc.NewProcessorCores(p.Id, p.TotalCores)
+				ch <- mc.NewProcessorThreads(p.Id, p.TotalThreads)
+			}
+			if p.MaxSpeedMHz > 0 {
+				ch <- mc.NewProcessorMaxSpeed(p.Id, p.MaxSpeedMHz)
+			}
+			if p.OperatingSpeedMHz > 0 {
+				ch <- mc.NewProcessorOperatingSpeed(p.Id, p.OperatingSpeedMHz)
+			}
+
+			if p.Metrics.OdataId == "" {
+				return
+			}
+			var m ProcessorMetrics
+			if err := client.redfishGet(p.Metrics.OdataId, &m); err != nil {
+				log.Println(err)
+				return
+			}
+			mc.emitProcessorMetrics(ch, p.Id, &m)
+		}(c)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (mc *Collector) emitProcessorMetrics(ch chan string, id string, m *ProcessorMetrics) {
+	if m.TemperatureCelsius != nil {
+		ch <- mc.NewProcessorTemperature(id, *m.TemperatureCelsius)
+	}
+	if m.ThrottlingCelsius != nil {
+		ch <- mc.NewProcessorThrottlingCelsius(id, *m.ThrottlingCelsius)
+	}
+	if m.Throttled != nil {
+		ch <- mc.NewProcessorThrottled(id, *m.Throttled)
+	}
+	if m.CacheMetricsTotal == nil || m.CacheMetricsTotal.LifeTime == nil {
+		return
+	}
+	lifeTime := m.CacheMetricsTotal.LifeTime
+	if lifeTime.CorrectableECCErrorCount != nil {
+		ch <- mc.NewProcessorCacheErrors(id, "correctable", *lifeTime.CorrectableECCErrorCount)
+	}
+	if lifeTime.UncorrectableECCErrorCount != nil {
+		ch <- mc.NewProcessorCacheErrors(id, "uncorrectable", *lifeTime.UncorrectableECCErrorCount)
+	}
+}
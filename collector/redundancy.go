@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"strconv"
+	"strings"
+)
+
+// redundancySets merges the redundancy groups of a resource with the ones its members embed,
+// members usually only link back to a group of the resource so those are skipped, and some bmcs
+// embed a full copy of the group under every member without @odata.id, those are the same group
+// when MemberId, Name and Mode match
+func redundancySets(sets []Redundancy, members ...[]Redundancy) []Redundancy {
+	copyKey := func(r Redundancy) string {
+		return r.MemberId + "/" + r.Name + "/" + string(r.Mode)
+	}
+	sets = redundancyPointers("", sets)
+	seen := make(map[string]bool)
+	copies := make(map[string]bool)
+	for _, r := range sets {
+		seen[r.OdataId] = true
+		copies[copyKey(r)] = true
+	}
+	for _, embedded := range members {
+		for _, r := range embedded {
+			if r.Name == "" && r.Mode == "" && r.Status.State == "" {
+				continue
+			}
+			if r.OdataId != "" && seen[r.OdataId] || copies[copyKey(r)] {
+				continue
+			}
+			seen[r.OdataId] = true
+			copies[copyKey(r)] = true
+			sets = append(sets, r)
+		}
+	}
+	return sets
+}
+
+// redundancyPointers fills in the json pointer of groups the bmc sends without @odata.id, parent is the
+// pointer of the member embedding them, e.g. /Fans/0, or empty for the groups of the resource itself
+func redundancyPointers(parent string, sets []Redundancy) []Redundancy {
+	filled := make([]Redundancy, len(sets))
+	for i, r := range sets {
+		if r.OdataId == "" {
+			r.OdataId = "#" + parent + "/Redundancy/" + strconv.Itoa(i)
+		}
+		filled[i] = r
+	}
+	return filled
+}
+
+// GetId returns the json pointer of the group within its resource, e.g. Fans/0/Redundancy/0 for
+// /redfish/v1/Chassis/1/Thermal#/Fans/0/Redundancy/0, the MemberId alone collides between a fan's
+// group and the resource's own group
+func (r *Redundancy) GetId(fallback int) string {
+	if _, pointer, ok := strings.Cut(r.OdataId, "#"); ok && strings.Trim(pointer, "/") != "" {
+		return strings.Trim(pointer, "/")
+	}
+	if len(r.MemberId) > 0 {
+		return r.MemberId
+	}
+	if i := strings.LastIndex(r.OdataId, "/"); i >= 0 && i < len(r.OdataId)-1 {
+		return r.OdataId[i+1:]
+	}
+	return strconv.Itoa(fallback)
+}
+
+func (client *Client) refreshRedundancy(mc *Collector, ch chan string, group string, sets []Redundancy) {
+	for n, r := range sets {
+		if r.Status.State == StateAbsent {
+			continue
+		}
+
+		id := r.GetId(n)
+		mode := string(r.Mode)
+		ch <- mc.NewRedundancyHealth(group, id, r.Name, mode, client.profile.Health(r.Status))
+		ch <- mc.NewRedundancyEnabled(group, id, r.Name, r.RedundancyEnabled)
+		ch <- mc.NewRedundancyMembers(group, id, r.Name, len(r.RedundancySet))
+		ch <- mc.NewRedundancyMinNeeded(group, id, r.Name, r.MinNumNeeded)
+		ch <- mc.NewRedundancyMaxSupported(group, id, r.Name, r.MaxNumSupported)
+	}
+}
@@ -0,0 +1,51 @@
+package collector
+
+import "testing"
+
+func TestRedundancySets(t *testing.T) {
+	top := []Redundancy{
+		{OdataId: "/redfish/v1/Chassis/1/Thermal#/Redundancy/0", MemberId: "0", Name: "Fan Redundancy", Mode: "N+m"},
+	}
+	fan0 := redundancyPointers("/Fans/0", []Redundancy{
+		{OdataId: "/redfish/v1/Chassis/1/Thermal#/Redundancy/0"},
+	})
+	fan1 := redundancyPointers("/Fans/1", []Redundancy{
+		{MemberId: "0", Name: "Fan Redundancy", Mode: "N+m"},
+	})
+	fan2 := redundancyPointers("/Fans/2", []Redundancy{
+		{OdataId: "/redfish/v1/Chassis/1/Thermal#/Redundancy/0", MemberId: "0", Name: "Fan Redundancy", Mode: "N+m"},
+	})
+
+	fan3 := redundancyPointers("/Fans/3", []Redundancy{
+		{MemberId: "0", Name: "Fan3 Redundancy", Mode: "N+m"},
+	})
+
+	got := redundancySets(top, fan0, fan1, fan2, fan3)
+	want := []string{"Redundancy/0", "Fans/3/Redundancy/0"}
+	if len(got) != len(want) {
+		t.Fatalf("redundancySets = %+v, want ids %v", got, want)
+	}
+	for i, r := range got {
+		if id := r.GetId(i); id != want[i] {
+			t.Errorf("redundancySets[%d].GetId() = %q, want %q", i, id, want[i])
+		}
+	}
+}
+
+func TestRedundancyGetId(t *testing.T) {
+	tests := []struct {
+		r    Redundancy
+		want string
+	}{
+		{Redundancy{OdataId: "/redfish/v1/Chassis/1/Power#/Redundancy/0", MemberId: "0"}, "Redundancy/0"},
+		{Redundancy{OdataId: "/redfish/v1/Chassis/1/Thermal#/Fans/0/Redundancy/0", MemberId: "0"}, "Fans/0/Redundancy/0"},
+		{Redundancy{OdataId: "/redfish/v1/Chassis/1/Power/Redundancy/PSU", MemberId: "PSU"}, "PSU"},
+		{Redundancy{OdataId: "/redfish/v1/Chassis/1/Power/Redundancy/1"}, "1"},
+		{Redundancy{}, "3"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.GetId(3); got != tt.want {
+			t.Errorf("GetId(%q, %q) = %q, want %q", tt.r.OdataId, tt.r.MemberId, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRetryAttempts  = 3
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 10 * time.Second
+	defaultRetryMaxTotal  = 30 * time.Second
+)
+
+var (
+	retryAttempts  = defaultRetryAttempts
+	retryBaseDelay = defaultRetryBaseDelay
+	retryMaxDelay  = defaultRetryMaxDelay
+	retryMaxTotal  = defaultRetryMaxTotal
+	retries        = map[string]map[string]int64{}
+	retriesMu      sync.Mutex
+)
+
+type retryKey struct{}
+
+// idempotent marks a non-GET request as safe to retry
+func idempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), retryKey{}, true))
+}
+
+func canRetry(req *http.Request) bool {
+	if req.Method == "GET" || req.Method == "HEAD" {
+		return true
+	}
+	ok, _ := req.Context().Value(retryKey{}).(bool)
+	return ok
+}
+
+// retryable reports whether the outcome is transient and the code it is counted under, timeouts are not
+func retryable(resp *http.Response, err error) (bool, string) {
+	if err != nil {
+		// a bmc that didn't answer within the client timeout won't answer a retry either
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+			return false, ""
+		}
+		return true, "transport"
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, strconv.Itoa(resp.StatusCode)
+	}
+	return false, ""
+}
+
+// retryDelay honours Retry-After and otherwise backs off exponentially with jitter
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if after := resp.Header.Get("Retry-After"); after != "" {
+			if secs, err := strconv.Atoi(after); err == nil {
+				return capDelay(time.Duration(secs) * time.Second)
+			}
+			if t, err := http.ParseTime(after); err == nil {
+				return capDelay(time.Until(t))
+			}
+		}
+	}
+
+	delay := capDelay(retryBaseDelay << attempt)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func capDelay(delay time.Duration) time.Duration {
+	if delay < 0 {
+		return 0
+	}
+	if delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return delay
+}
+
+func countRetry(host, code string) {
+	retriesMu.Lock()
+	defer retriesMu.Unlock()
+	if retries[host] == nil {
+		retries[host] = map[string]int64{}
+	}
+	retries[host][code]++
+}
+
+func retryStats(host string) ([]string, map[string]int64) {
+	retriesMu.Lock()
+	defer retriesMu.Unlock()
+	stats := make(map[string]int64, len(retries[host]))
+	codes := make([]string, 0, len(retries[host]))
+	for code, n := range retries[host] {
+		stats[code] = n
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes, stats
+}
@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCanRetry(t *testing.T) {
+	get := httptest.NewRequest("GET", "/redfish/v1", nil)
+	post := httptest.NewRequest("POST", "/redfish/v1/EventService/Subscriptions", nil)
+	tests := []struct {
+		req  *http.Request
+		want bool
+	}{
+		{get, true},
+		{httptest.NewRequest("HEAD", "/redfish/v1", nil), true},
+		{post, false},
+		{idempotent(post), true},
+	}
+	for _, tt := range tests {
+		if got := canRetry(tt.req); got != tt.want {
+			t.Errorf("canRetry(%s) = %v, want %v", tt.req.Method, got, tt.want)
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		code int
+		err  error
+		want bool
+		key  string
+	}{
+		{0, errors.New("connection reset"), true, "transport"},
+		{0, &url.Error{Op: "Get", URL: "https://10.0.0.1/redfish/v1", Err: timeoutError{}}, false, ""},
+		{0, fmt.Errorf("read body: %w", context.DeadlineExceeded), false, ""},
+		{http.StatusTooManyRequests, nil, true, "429"},
+		{http.StatusBadGateway, nil, true, "502"},
+		{http.StatusServiceUnavailable, nil, true, "503"},
+		{http.StatusGatewayTimeout, nil, true, "504"},
+		{http.StatusInternalServerError, nil, false, ""},
+		{http.StatusNotFound, nil, false, ""},
+		{http.StatusOK, nil, false, ""},
+	}
+	for _, tt := range tests {
+		var resp *http.Response
+		if tt.err == nil {
+			resp = &http.Response{StatusCode: tt.code}
+		}
+		got, key := retryable(resp, tt.err)
+		if got != tt.want || key != tt.key {
+			t.Errorf("retryable(%d, %v) = %v, %q, want %v, %q", tt.code, tt.err, got, key, tt.want, tt.key)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	header := func(after string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{after}}}
+	}
+	tests := []struct {
+		name     string
+		attempt  int
+		resp     *http.Response
+		min, max time.Duration
+	}{
+		{"first backoff", 0, nil, retryBaseDelay / 2, retryBaseDelay},
+		{"second backoff", 1, nil, retryBaseDelay, 2 * retryBaseDelay},
+		{"capped backoff", 20, nil, retryMaxDelay / 2, retryMaxDelay},
+		{"retry-after seconds", 0, header("3"), 3 * time.Second, 3 * time.Second},
+		{"retry-after over the cap", 0, header("3600"), retryMaxDelay, retryMaxDelay},
+		{"retry-after in the past", 0, header(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)), 0, 0},
+		{"unparsable retry-after", 0, header("soon"), retryBaseDelay / 2, retryBaseDelay},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempt, tt.resp); got < tt.min || got > tt.max {
+			t.Errorf("%s: retryDelay() = %v, want between %v and %v", tt.name, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryStats(t *testing.T) {
+	host := "retry-stats-test"
+	countRetry(host, "503")
+	countRetry(host, "transport")
+	countRetry(host, "503")
+	codes, stats := retryStats(host)
+	if len(codes) != 2 || codes[0] != "503" || codes[1] != "transport" {
+		t.Fatalf("retryStats codes = %v, want [503 transport]", codes)
+	}
+	if stats["503"] != 2 || stats["transport"] != 1 {
+		t.Errorf("retryStats = %v, want 503:2 transport:1", stats)
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "Client.Timeout exceeded while awaiting headers" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
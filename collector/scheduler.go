@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"server_exporter/config"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultMaxPerHost = 4
+	defaultMaxTotal   = 64
+)
+
+var requestScheduler = newScheduler(defaultMaxTotal, defaultMaxPerHost)
+
+// scheduler bounds the in-flight redfish requests per bmc and across all bmcs
+type scheduler struct {
+	global  chan struct{}
+	perHost int
+	mu      sync.Mutex
+	hosts   map[string]*hostQueue
+}
+
+type hostQueue struct {
+	slots     chan struct{}
+	requests  int64
+	inFlight  int64
+	waitNanos int64
+}
+
+func newScheduler(maxTotal, maxPerHost int) *scheduler {
+	if maxTotal <= 0 {
+		maxTotal = defaultMaxTotal
+	}
+	if maxPerHost <= 0 {
+		maxPerHost = defaultMaxPerHost
+	}
+	return &scheduler{
+		global:  make(chan struct{}, maxTotal),
+		perHost: maxPerHost,
+		hosts:   map[string]*hostQueue{},
+	}
+}
+
+// Init applies the process wide settings of the collector package
+func Init(conf config.Config) {
+	requestScheduler = newScheduler(conf.Concurrency.MaxTotal, conf.Concurrency.MaxPerHost)
+
+	if conf.Breaker.FailureThreshold > 0 {
+		breakerThreshold = conf.Breaker.FailureThreshold
+	}
+	if conf.Breaker.Cooldown > 0 {
+		breakerCooldown = time.Duration(conf.Breaker.Cooldown) * time.Second
+	}
+	if conf.Breaker.MaxCooldown > 0 {
+		breakerMaxBackoff = time.Duration(conf.Breaker.MaxCooldown) * time.Second
+	}
+
+	if conf.Retry.MaxAttempts > 0 {
+		retryAttempts = conf.Retry.MaxAttempts
+	}
+	if conf.Retry.BaseDelay > 0 {
+		retryBaseDelay = time.Duration(conf.Retry.BaseDelay) * time.Millisecond
+	}
+	if conf.Retry.MaxDelay > 0 {
+		retryMaxDelay = time.Duration(conf.Retry.MaxDelay) * time.Millisecond
+	}
+	if conf.Retry.MaxTotal > 0 {
+		retryMaxTotal = time.Duration(conf.Retry.MaxTotal) * time.Millisecond
+	}
+}
+
+func (s *scheduler) queue(host string) *hostQueue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.hosts[host]
+	if !ok {
+		q = &hostQueue{slots: make(chan struct{}, s.perHost)}
+		s.hosts[host] = q
+	}
+	return q
+}
+
+// acquire blocks until the host and the global limit allow another request
+func (s *scheduler) acquire(host string) (release func()) {
+	q := s.queue(host)
+	start := time.Now()
+	// take the host slot first so a busy bmc doesn't hold global slots while queueing
+	q.slots <- struct{}{}
+	s.global <- struct{}{}
+	atomic.AddInt64(&q.waitNanos, int64(time.Since(start)))
+	atomic.AddInt64(&q.requests, 1)
+	atomic.AddInt64(&q.inFlight, 1)
+
+	return func() {
+		atomic.AddInt64(&q.inFlight, -1)
+		<-s.global
+		<-q.slots
+	}
+}
+
+func (s *scheduler) stats(host string) (requests, inFlight int64, wait float64) {
+	q := s.queue(host)
+	return atomic.LoadInt64(&q.requests), atomic.LoadInt64(&q.inFlight), time.Duration(atomic.LoadInt64(&q.waitNanos)).Seconds()
+}
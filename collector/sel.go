@@ -0,0 +1,160 @@
+package collector
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	selTrackers   = map[string]*selTracker{}
+	selTrackersMu sync.Mutex
+	labelReplacer = strings.NewReplacer(`\`, "/", `"`, "'", "\n", " ", "\r", " ")
+)
+
+type selKey struct {
+	severity  string
+	component string
+}
+
+// selTracker remembers the entries seen on a bmc by id and timestamp, so an id reused after
+// the log is cleared counts again. The seen set only grows with the reads and forgets an entry
+// once no read has returned it for selSeenTTL, an empty or partial read doesn't reset it
+type selTracker struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	counts map[selKey]int64
+	lastTs map[string]time.Time
+	latest []SelEntry
+	primed bool
+}
+
+// selSeenTTL is how long an entry missing from the reads is still remembered
+const selSeenTTL = 24 * time.Hour
+
+func selTrackerOf(host string) *selTracker {
+	selTrackersMu.Lock()
+	defer selTrackersMu.Unlock()
+	t, ok := selTrackers[host]
+	if !ok {
+		t = &selTracker{seen: map[string]time.Time{}, counts: map[selKey]int64{}, lastTs: map[string]time.Time{}}
+		selTrackers[host] = t
+	}
+	return t
+}
+
+// selSeverity unifies the severities of the vendors into lower case
+func selSeverity(severity string) string {
+	if severity == "" {
+		return "unknown"
+	}
+	return strings.ToLower(severity)
+}
+
+// labelValue keeps a free text label parseable by the outputs
+func labelValue(s string) string {
+	return labelReplacer.Replace(strings.TrimSpace(s))
+}
+
+const defaultSelLookback = 3600
+
+// selSince keeps the entries created within lookback seconds, entries without a time are dropped
+func selSince(entries []SelEntry, lookback int) []SelEntry {
+	if lookback < 0 {
+		return nil
+	}
+	if lookback == 0 {
+		lookback = defaultSelLookback
+	}
+	since := time.Now().Add(-time.Duration(lookback) * time.Second)
+	var recent []SelEntry
+	for _, e := range entries {
+		if e.Created.After(since) {
+			recent = append(recent, e)
+		}
+	}
+	return recent
+}
+
+func selEntryKey(e SelEntry) string {
+	return e.Id + "@" + e.Created.String()
+}
+
+// observe counts the entries not seen before and returns them, entries of the first read
+// are counted but reported as history through initial
+func (t *selTracker) observe(entries []SelEntry, latest int) (fresh []SelEntry, initial bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	seen := make(map[string]bool, len(entries))
+	unique := make([]SelEntry, 0, len(entries))
+	for _, e := range entries {
+		key := selEntryKey(e)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, e)
+		_, known := t.seen[key]
+		t.seen[key] = now
+		if known {
+			continue
+		}
+		severity := selSeverity(e.Severity)
+		t.counts[selKey{severity, e.Component}]++
+		if e.Created.After(t.lastTs[severity]) {
+			t.lastTs[severity] = e.Created
+		}
+		fresh = append(fresh, e)
+	}
+	for key, at := range t.seen {
+		if now.Sub(at) > selSeenTTL {
+			delete(t.seen, key)
+		}
+	}
+	initial = !t.primed
+	t.primed = true
+
+	// an empty read says nothing about the log, keep showing the entries of the last one
+	if len(unique) == 0 {
+		return fresh, initial
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		return unique[i].Created.After(unique[j].Created)
+	})
+	if latest < 0 {
+		latest = 0
+	}
+	if len(unique) > latest {
+		unique = unique[:latest]
+	}
+	t.latest = unique
+	return fresh, initial
+}
+
+func (t *selTracker) emit(mc *Collector, ch chan string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := make([]selKey, 0, len(t.counts))
+	for k := range t.counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].severity != keys[j].severity {
+			return keys[i].severity < keys[j].severity
+		}
+		return keys[i].component < keys[j].component
+	})
+	for _, k := range keys {
+		ch <- mc.NewSelEntries(k.severity, labelValue(k.component), t.counts[k])
+	}
+	for severity, ts := range t.lastTs {
+		ch <- mc.NewSelLastEntryTimestamp(severity, ts)
+	}
+	for i, e := range t.latest {
+		ch <- mc.NewSelLatestEntry(i, e)
+	}
+}
@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelSince(t *testing.T) {
+	now := time.Now()
+	entries := []SelEntry{
+		{Id: "old", Created: now.Add(-2 * time.Hour)},
+		{Id: "recent", Created: now.Add(-10 * time.Minute)},
+		{Id: "untimed"},
+	}
+	tests := []struct {
+		lookback int
+		want     []string
+	}{
+		{0, []string{"recent"}},
+		{3 * 3600, []string{"old", "recent"}},
+		{60, nil},
+		{-1, nil},
+	}
+	for _, tt := range tests {
+		got := selSince(entries, tt.lookback)
+		if len(got) != len(tt.want) {
+			t.Errorf("selSince(%d) = %v, want %v", tt.lookback, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Id != tt.want[i] {
+				t.Errorf("selSince(%d) = %v, want %v", tt.lookback, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSelTrackerObserve(t *testing.T) {
+	base := time.Date(2021, 9, 9, 8, 0, 0, 0, time.UTC)
+	entry := func(id, severity string, minutes int) SelEntry {
+		return SelEntry{Id: id, Severity: severity, Component: "Power Supply", Created: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	tracker := &selTracker{seen: map[string]time.Time{}, counts: map[selKey]int64{}, lastTs: map[string]time.Time{}}
+	tests := []struct {
+		name    string
+		entries []SelEntry
+		fresh   []string
+		initial bool
+		latest  []string
+	}{
+		{"first read is history", []SelEntry{entry("1", "Critical", 0), entry("2", "OK", 1)}, []string{"1", "2"}, true, []string{"2", "1"}},
+		{"only new entries are fresh", []SelEntry{entry("1", "Critical", 0), entry("2", "OK", 1), entry("3", "Warning", 2)}, []string{"3"}, false, []string{"3", "2"}},
+		{"duplicates within a read count once", []SelEntry{entry("3", "Warning", 2), entry("4", "Critical", 3), entry("4", "Critical", 3)}, []string{"4"}, false, []string{"4", "3"}},
+		{"cleared log reusing an id", []SelEntry{entry("1", "Critical", 10)}, []string{"1"}, false, []string{"1"}},
+		{"empty read keeps the tracker", nil, nil, false, []string{"1"}},
+		{"partial read keeps the tracker", []SelEntry{entry("2", "OK", 1)}, nil, false, []string{"2"}},
+		{"full read after a partial one", []SelEntry{entry("1", "Critical", 0), entry("2", "OK", 1), entry("3", "Warning", 2), entry("4", "Critical", 3), entry("1", "Critical", 10), entry("5", "OK", 20)}, []string{"5"}, false, []string{"5", "1"}},
+	}
+	for _, tt := range tests {
+		fresh, initial := tracker.observe(tt.entries, 2)
+		if initial != tt.initial || !sameSelIds(fresh, tt.fresh) || !sameSelIds(tracker.latest, tt.latest) {
+			t.Errorf("%s: observe() = %v, %v latest %v, want %v, %v latest %v",
+				tt.name, selIds(fresh), initial, selIds(tracker.latest), tt.fresh, tt.initial, tt.latest)
+		}
+	}
+
+	counts := map[selKey]int64{
+		{"critical", "Power Supply"}: 3,
+		{"ok", "Power Supply"}:       2,
+		{"warning", "Power Supply"}:  1,
+	}
+	if len(tracker.counts) != len(counts) {
+		t.Errorf("counts = %v, want %v", tracker.counts, counts)
+	}
+	for k, n := range counts {
+		if tracker.counts[k] != n {
+			t.Errorf("counts[%v] = %d, want %d", k, tracker.counts[k], n)
+		}
+	}
+	if ts := tracker.lastTs["critical"]; !ts.Equal(base.Add(10 * time.Minute)) {
+		t.Errorf("lastTs[critical] = %v, want %v", ts, base.Add(10*time.Minute))
+	}
+}
+
+func selIds(entries []SelEntry) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.Id)
+	}
+	return ids
+}
+
+func sameSelIds(entries []SelEntry, ids []string) bool {
+	got := selIds(entries)
+	if len(got) != len(ids) {
+		return false
+	}
+	for i := range got {
+		if got[i] != ids[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSelTrackerForgetsOldEntries(t *testing.T) {
+	e := SelEntry{Id: "1", Severity: "Critical", Created: time.Now()}
+	tracker := &selTracker{seen: map[string]time.Time{}, counts: map[selKey]int64{}, lastTs: map[string]time.Time{}}
+	tracker.observe([]SelEntry{e}, 1)
+	tracker.seen[selEntryKey(e)] = time.Now().Add(-selSeenTTL - time.Minute)
+	tracker.observe(nil, 1)
+	if len(tracker.seen) != 0 {
+		t.Errorf("seen = %v, want entries missing for longer than %v forgotten", tracker.seen, selSeenTTL)
+	}
+	if fresh, _ := tracker.observe([]SelEntry{e}, 1); len(fresh) != 1 {
+		t.Errorf("observe() after forgetting = %v, want the entry counted again", selIds(fresh))
+	}
+}
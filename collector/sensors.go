@@ -0,0 +1,103 @@
+package collector
+
+import (
+	"strconv"
+	"strings"
+)
+
+// sensorThreshold is one of the six thresholds redfish defines for a sensor reading
+type sensorThreshold struct {
+	name  string
+	value float64
+}
+
+// sensorNumber reads a numeric sensor field, vendors send null, numbers or numbers quoted as strings
+func sensorNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+func sensorThresholds(lowerNonCritical, lowerCritical, lowerFatal, upperNonCritical, upperCritical, upperFatal any) []sensorThreshold {
+	var thresholds []sensorThreshold
+	for _, t := range []struct {
+		name  string
+		value any
+	}{
+		{"lower_noncritical", lowerNonCritical},
+		{"lower_critical", lowerCritical},
+		{"lower_fatal", lowerFatal},
+		{"upper_noncritical", upperNonCritical},
+		{"upper_critical", upperCritical},
+		{"upper_fatal", upperFatal},
+	} {
+		if value, ok := sensorNumber(t.value); ok {
+			thresholds = append(thresholds, sensorThreshold{t.name, value})
+		}
+	}
+	return thresholds
+}
+
+// thresholdState reports the most severe threshold the reading has crossed, normal when none has been
+func thresholdState(reading float64, thresholds []sensorThreshold) string {
+	state, rank := "normal", 0
+	for _, t := range thresholds {
+		level, severity, _ := strings.Cut(t.name, "_")
+		crossed := level == "lower" && reading <= t.value || level == "upper" && reading >= t.value
+		if !crossed {
+			continue
+		}
+		if r := thresholdRank[severity]; r > rank {
+			state, rank = severity, r
+		}
+	}
+	return state
+}
+
+// fanThresholdsComparable reports whether the fan thresholds are in the units of the reading, some bmcs
+// report the reading in percent but keep the thresholds in rpm, those thresholds can't be compared
+func fanThresholdsComparable(units string, thresholds []sensorThreshold) bool {
+	if units != "percent" {
+		return true
+	}
+	for _, t := range thresholds {
+		if t.value < 0 || t.value > 100 {
+			return false
+		}
+	}
+	return true
+}
+
+var thresholdRank = map[string]int{
+	"normal":      0,
+	"noncritical": 1,
+	"critical":    2,
+	"fatal":       3,
+}
+
+func (client *Client) refreshVoltages(mc *Collector, ch chan string, voltages []Voltage) {
+	for n, v := range voltages {
+		if v.Status.State != StateEnabled && v.Status.State != "ENABLE" {
+			continue
+		}
+
+		id := v.GetId(n)
+		ch <- mc.NewSensorsVoltageHealth(id, v.Name, v.PhysicalContext, client.profile.Health(v.Status))
+		thresholds := v.thresholds()
+		for _, t := range thresholds {
+			ch <- mc.NewSensorsVoltageThreshold(t.value, id, v.Name, v.PhysicalContext, t.name)
+		}
+		if reading, ok := sensorNumber(v.ReadingVolts); ok {
+			ch <- mc.NewSensorsVoltage(reading, id, v.Name, v.PhysicalContext)
+			ch <- mc.NewSensorThresholdState("voltage", id, v.Name, v.PhysicalContext, thresholdState(reading, thresholds))
+		}
+	}
+}
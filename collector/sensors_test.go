@@ -0,0 +1,85 @@
+package collector
+
+import "testing"
+
+func TestSensorNumber(t *testing.T) {
+	tests := []struct {
+		in   any
+		want float64
+		ok   bool
+	}{
+		{42.5, 42.5, true},
+		{" 12 ", 12, true},
+		{"n/a", 0, false},
+		{nil, 0, false},
+		{true, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := sensorNumber(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("sensorNumber(%v) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestThresholdState(t *testing.T) {
+	thresholds := sensorThresholds(10.0, 5.0, nil, 70.0, "80", 90.0)
+	tests := []struct {
+		reading float64
+		want    string
+	}{
+		{40, "normal"},
+		{10, "noncritical"},
+		{3, "critical"},
+		{75, "noncritical"},
+		{85, "critical"},
+		{95, "fatal"},
+	}
+	for _, tt := range tests {
+		if got := thresholdState(tt.reading, thresholds); got != tt.want {
+			t.Errorf("thresholdState(%v) = %q, want %q", tt.reading, got, tt.want)
+		}
+	}
+	if got := thresholdState(1000, nil); got != "normal" {
+		t.Errorf("thresholdState without thresholds = %q, want normal", got)
+	}
+}
+
+func TestFanThresholdsComparable(t *testing.T) {
+	rpm := []sensorThreshold{{"lower_critical", 480}}
+	percent := []sensorThreshold{{"lower_critical", 10}}
+	tests := []struct {
+		units      string
+		thresholds []sensorThreshold
+		want       bool
+	}{
+		{"rpm", rpm, true},
+		{"percent", percent, true},
+		{"percent", rpm, false},
+		{"percent", nil, true},
+	}
+	for _, tt := range tests {
+		if got := fanThresholdsComparable(tt.units, tt.thresholds); got != tt.want {
+			t.Errorf("fanThresholdsComparable(%q, %v) = %v, want %v", tt.units, tt.thresholds, got, tt.want)
+		}
+	}
+}
+
+func TestFanGetReading(t *testing.T) {
+	tests := []struct {
+		fan  Fan
+		want float64
+		ok   bool
+	}{
+		{Fan{Reading: 3000.0}, 3000, true},
+		{Fan{CurrentReading: 2400.0}, 2400, true},
+		{Fan{Reading: 0.0}, 0, true},
+		{Fan{}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.fan.GetReading()
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("GetReading(%v, %v) = %v, %v, want %v, %v", tt.fan.Reading, tt.fan.CurrentReading, got, ok, tt.want, tt.ok)
+		}
+	}
+}
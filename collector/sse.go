@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"server_exporter/config"
+	"server_exporter/tools"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sseMinBackoff         = time.Second
+	sseMaxBackoff         = 5 * time.Minute
+	defaultSseIdleTimeout = 10 * time.Minute
+)
+
+var (
+	streams   = map[string]*streamState{}
+	streamsMu sync.Mutex
+)
+
+type streamState struct {
+	connected bool
+	connects  int64
+}
+
+func streamStateOf(host string) *streamState {
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+	s, ok := streams[host]
+	if !ok {
+		s = &streamState{}
+		streams[host] = s
+	}
+	return s
+}
+
+func setStreamConnected(host string, connected bool) {
+	s := streamStateOf(host)
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+	if connected && !s.connected {
+		s.connects++
+	}
+	s.connected = connected
+}
+
+// streamStats reports whether the stream of a host is connected and how often it connected,
+// ok is false for hosts without a stream
+func streamStats(host string) (connected bool, connects int64, ok bool) {
+	streamsMu.Lock()
+	defer streamsMu.Unlock()
+	s, ok := streams[host]
+	if !ok {
+		return false, 0, false
+	}
+	return s.connected, s.connects, true
+}
+
+// Stream holds the server-sent event stream of a bmc open, it connects with backoff
+// and resumes after the last event it received, it never returns
+func Stream(authInfo tools.Data, deviceName string, conf config.Config) {
+	client := clientOf(authInfo, deviceName)
+	streamStateOf(client.host)
+	// the stream stays open, it must neither time out nor hold a slot of the scheduler,
+	// one transport serves all reconnects so no idle connections are left behind. A half-open
+	// connection is caught by the tcp keep-alives and the idle timeout of the stream
+	httpClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: time.Minute,
+	}}
+	idleTimeout := defaultSseIdleTimeout
+	if conf.Events.SseIdleTimeout > 0 {
+		idleTimeout = time.Duration(conf.Events.SseIdleTimeout) * time.Second
+	}
+	lastId := ""
+	backoff := sseMinBackoff
+	for {
+		start := time.Now()
+		err := client.stream(httpClient, conf.Outputs, idleTimeout, &lastId)
+		setStreamConnected(client.host, false)
+		dropMetricReports(client.host)
+		httpClient.CloseIdleConnections()
+		if time.Since(start) > sseMaxBackoff {
+			backoff = sseMinBackoff
+		}
+		log.Printf("event stream of %s closed-%v, reconnecting in %s", client.host, err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > sseMaxBackoff {
+			backoff = sseMaxBackoff
+		}
+	}
+}
+
+// stream reads the event stream until it fails, ends or sends nothing for idleTimeout
+func (client *Client) stream(httpClient *http.Client, outputs config.Outputs, idleTimeout time.Duration, lastId *string) error {
+	err := client.ensureEndpoints()
+	if err != nil {
+		return err
+	}
+	if client.eventServicePath == "" {
+		return fmt.Errorf("bmc has no event service")
+	}
+	var service EventServiceResponse
+	err = client.redfishGet(client.eventServicePath, &service)
+	if err != nil {
+		return err
+	}
+	if service.ServerSentEventUri == "" {
+		return fmt.Errorf("bmc has no server-sent event uri")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+client.host+service.ServerSentEventUri, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "text/event-stream")
+	req.SetBasicAuth(client.username, client.password)
+	if *lastId != "" {
+		req.Header.Add("Last-Event-ID", *lastId)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+	setStreamConnected(client.host, true)
+
+	// every line, keep-alive comments included, shows the connection is alive
+	idle := time.AfterFunc(idleTimeout, cancel)
+	defer idle.Stop()
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		idle.Reset(idleTimeout)
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				client.handleStreamData(outputs, data.String())
+				data.Reset()
+			}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			*lastId = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(value)
+		}
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("no data for %s", idleTimeout)
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("stream ended")
+}
+
+// handleStreamData dispatches one stream message, a MetricReport or an Event
+func (client *Client) handleStreamData(outputs config.Outputs, data string) {
+	var kind struct {
+		OdataType string `json:"@odata.type"`
+	}
+	if err := json.Unmarshal([]byte(data), &kind); err != nil {
+		log.Printf("fail to decode stream message of %s-%s", client.host, err)
+		return
+	}
+
+	if strings.Contains(kind.OdataType, "MetricReport") {
+		var report MetricReport
+		if err := json.Unmarshal([]byte(data), &report); err != nil {
+			log.Printf("fail to decode metric report of %s-%s", client.host, err)
+			return
+		}
+		storeMetricReport(client.host, &report)
+		return
+	}
+
+	var payload EventPayload
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		log.Printf("fail to decode event of %s-%s", client.host, err)
+		return
+	}
+	handleEvents(outputs, "sse", client.host, client.getDeviceName(), &payload)
+}
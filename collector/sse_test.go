@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"server_exporter/config"
+	"server_exporter/tools"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamIdleTimeout(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/EventService" {
+			fmt.Fprint(w, `{"ServerSentEventUri": "/redfish/v1/SSE"}`)
+			return
+		}
+		// one keep-alive, then the connection goes silent like a half-open one
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	var auth tools.Data
+	auth.Dat.Host = strings.TrimPrefix(srv.URL, "https://")
+	client := newClient(auth, "")
+	client.discoveredAt = time.Now()
+	client.eventServicePath = "/redfish/v1/EventService"
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+
+	lastId := ""
+	errc := make(chan error, 1)
+	go func() {
+		errc <- client.stream(httpClient, config.Outputs{}, 100*time.Millisecond, &lastId)
+	}()
+	select {
+	case err := <-errc:
+		if err == nil || !strings.Contains(err.Error(), "no data") {
+			t.Errorf("stream() = %v, want the idle timeout", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream() still open after the idle timeout")
+	}
+}
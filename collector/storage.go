@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"strconv"
+)
+
+// smartArrayController turns an HPE SmartStorage array controller into the standard controller shape
+func (s *StorageController) smartArrayController() StorageControllerUnit {
+	unit := StorageControllerUnit{
+		MemberId:     s.Id,
+		Name:         s.Name,
+		Manufacturer: "HPE",
+		Model:        s.Model,
+		SerialNumber: s.SerialNumber,
+		Status:       s.Status,
+	}
+	if s.FirmwareVersion != nil {
+		unit.FirmwareVersion = s.FirmwareVersion.Current.VersionString
+	}
+	if s.CacheMemorySizeMiB > 0 || s.CacheModuleStatus != nil {
+		unit.CacheSummary = &CacheSummary{TotalCacheSizeMiB: s.CacheMemorySizeMiB}
+		// older iLO firmware reports the cache module health as a bare string
+		switch v := s.CacheModuleStatus.(type) {
+		case string:
+			unit.CacheSummary.Status.Health = v
+		case map[string]any:
+			unit.CacheSummary.Status.Health, _ = v["Health"].(string)
+		}
+	}
+	return unit
+}
+
+// battery returns the cache backup battery of a storage resource, reported only through OEM fields
+func (s *StorageController) battery() (name, health string, ok bool) {
+	if s.Oem.Dell != nil && s.Oem.Dell.DellControllerBattery != nil {
+		b := s.Oem.Dell.DellControllerBattery
+		return b.Name, batteryHealth(b.PrimaryStatus), true
+	}
+	switch s.BackupPowerSourceStatus {
+	case "Present":
+		return "Backup Power Source", "OK", true
+	case "NotPresent":
+		// a controller without cache never has one, only report the missing battery when there is cache to protect
+		if s.CacheMemorySizeMiB > 0 {
+			return "Backup Power Source", "Warning", true
+		}
+	}
+	return "", "", false
+}
+
+// batteryHealth maps the Dell PrimaryStatus to the redfish health values
+func batteryHealth(status string) string {
+	switch status {
+	case "Degraded":
+		return "Warning"
+	case "Error":
+		return "Critical"
+	}
+	return status
+}
+
+func (client *Client) refreshStorageControllers(mc *Collector, ch chan string, ctlrs []StorageController) {
+	for _, s := range ctlrs {
+		for n, c := range s.StorageControllers {
+			if c.Status.State == StateAbsent {
+				continue
+			}
+
+			id := c.MemberId
+			if id == "" {
+				id = strconv.Itoa(n)
+			}
+			ch <- mc.NewStorageControllerInfo(s.Id, id, c.Name, c.Manufacturer, c.Model, c.SerialNumber, c.FirmwareVersion)
+			ch <- mc.NewStorageControllerHealth(s.Id, id, client.profile.Health(c.Status))
+			if c.SpeedGbps > 0 {
+				ch <- mc.NewStorageControllerSpeed(s.Id, id, c.SpeedGbps)
+			}
+			if c.CacheSummary != nil {
+				ch <- mc.NewStorageControllerCacheBytes(s.Id, id, c.CacheSummary.TotalCacheSizeMiB)
+				if c.CacheSummary.Status.Health != "" {
+					ch <- mc.NewStorageControllerCacheHealth(s.Id, id, c.CacheSummary.Status.Health)
+				}
+			}
+		}
+
+		if name, health, ok := s.battery(); ok {
+			ch <- mc.NewStorageBatteryHealth(s.Id, name, health)
+		}
+	}
+}
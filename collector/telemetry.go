@@ -0,0 +1,190 @@
+package collector
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultMetricReportMaxAge = 10 * time.Minute
+
+var (
+	metricReports   = map[string]map[string]*storedReport{}
+	metricReportsMu sync.Mutex
+)
+
+type storedReport struct {
+	report     *MetricReport
+	receivedAt time.Time
+}
+
+// storeMetricReport keeps the latest report of every id pushed by a bmc
+func storeMetricReport(host string, report *MetricReport) {
+	metricReportsMu.Lock()
+	defer metricReportsMu.Unlock()
+	if metricReports[host] == nil {
+		metricReports[host] = map[string]*storedReport{}
+	}
+	metricReports[host][report.Id] = &storedReport{report: report, receivedAt: time.Now()}
+}
+
+// dropMetricReports forgets the pushed reports of a host, they are not current once its stream is down
+func dropMetricReports(host string) {
+	metricReportsMu.Lock()
+	defer metricReportsMu.Unlock()
+	delete(metricReports, host)
+}
+
+// storedMetricReports returns the pushed reports of a host received within maxAge, older ones are dropped
+func storedMetricReports(host string, maxAge time.Duration) []*MetricReport {
+	metricReportsMu.Lock()
+	defer metricReportsMu.Unlock()
+	reports := make([]*MetricReport, 0, len(metricReports[host]))
+	for id, r := range metricReports[host] {
+		if time.Since(r.receivedAt) > maxAge {
+			delete(metricReports[host], id)
+			continue
+		}
+		reports = append(reports, r.report)
+	}
+	if len(metricReports[host]) == 0 {
+		delete(metricReports, host)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Id < reports[j].Id
+	})
+	return reports
+}
+
+func (collector *Collector) metricReportMaxAge() time.Duration {
+	if maxAge := collector.config.Telemetry.MaxAge; maxAge > 0 {
+		return time.Duration(maxAge) * time.Second
+	}
+	return defaultMetricReportMaxAge
+}
+
+// emitMetricReport turns the numeric values of a report into samples stamped with the time
+// of the value or else of the report, other values are skipped. Reports with an AppendLimit hold
+// many values of one metric, only the newest is exported, and values older than the max age lose
+// their timestamp so prometheus does not reject them as out of bounds.
+func (collector *Collector) emitMetricReport(ch chan string, report *MetricReport) {
+	type sample struct {
+		value *MetricValue
+		ts    time.Time
+	}
+	type metricKey struct {
+		id       string
+		property string
+	}
+
+	reportTs := parseLogTime(report.Timestamp)
+	var keys []metricKey
+	newest := map[metricKey]sample{}
+	for i := range report.MetricValues {
+		v := &report.MetricValues[i]
+		if _, ok := v.GetValue(); !ok {
+			continue
+		}
+		ts := parseLogTime(v.Timestamp)
+		if ts.IsZero() {
+			ts = reportTs
+		}
+		key := metricKey{v.MetricId, v.MetricProperty}
+		prev, seen := newest[key]
+		if !seen {
+			keys = append(keys, key)
+		}
+		// appended values come oldest first, a later value with the same time still wins
+		if !seen || !ts.Before(prev.ts) {
+			newest[key] = sample{v, ts}
+		}
+	}
+
+	staleBefore := time.Now().Add(-collector.metricReportMaxAge())
+	for _, key := range keys {
+		s := newest[key]
+		value, _ := s.value.GetValue()
+		ts := s.ts
+		if ts.Before(staleBefore) {
+			ts = time.Time{}
+		}
+		ch <- collector.NewTelemetryMetric(report.Id, key.id, key.property, value, ts)
+	}
+}
+
+// metricReportPaths lists the reports of the enabled definitions, bmcs without definitions
+// get all their reports read
+func (client *Client) metricReportPaths(service *TelemetryServiceResponse) ([]string, error) {
+	var paths []string
+	if service.MetricReportDefinitions.OdataId != "" {
+		var group GroupResponse
+		err := client.redfishGet(service.MetricReportDefinitions.OdataId, &group)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range group.Members {
+			var def MetricReportDefinition
+			if err = client.redfishGet(c.OdataId, &def); err != nil {
+				log.Println(err)
+				continue
+			}
+			if def.Enabled() && def.MetricReport.OdataId != "" {
+				paths = append(paths, def.MetricReport.OdataId)
+			}
+		}
+		if len(group.Members) > 0 {
+			return paths, nil
+		}
+	}
+
+	var group GroupResponse
+	err := client.redfishGet(service.MetricReports.OdataId, &group)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range group.Members {
+		paths = append(paths, c.OdataId)
+	}
+	return paths, nil
+}
+
+func (client *Client) RefreshTelemetry(mc *Collector, ch chan string) error {
+	if client.telemetryPath == "" {
+		return nil
+	}
+	var service TelemetryServiceResponse
+	err := client.redfishGet(client.telemetryPath, &service)
+	if err != nil {
+		return err
+	}
+	paths, err := client.metricReportPaths(&service)
+	if err != nil {
+		return err
+	}
+
+	// the reports pushed over the stream are emitted by Collect, reading them again would duplicate the series
+	pushed := map[string]bool{}
+	for _, r := range storedMetricReports(client.host, mc.metricReportMaxAge()) {
+		pushed[r.Id] = true
+	}
+
+	var wg sync.WaitGroup
+	for _, path := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			var report MetricReport
+			if err := client.redfishGet(path, &report); err != nil {
+				log.Println(err)
+				return
+			}
+			if pushed[report.Id] {
+				return
+			}
+			mc.emitMetricReport(ch, &report)
+		}(path)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"encoding/json"
+	"server_exporter/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmitMetricReport(t *testing.T) {
+	now := time.Now().UTC()
+	old := now.Add(-2 * time.Hour)
+	report := &MetricReport{
+		Id:        "PowerStatistics",
+		Timestamp: now.Format(time.RFC3339),
+		MetricValues: []MetricValue{
+			{MetricId: "SystemPowerConsumption", MetricValue: json.RawMessage(`"200"`), Timestamp: now.Add(-2 * time.Minute).Format(time.RFC3339)},
+			{MetricId: "SystemPowerConsumption", MetricValue: json.RawMessage(`"230"`), Timestamp: now.Add(-time.Minute).Format(time.RFC3339)},
+			{MetricId: "SystemPowerConsumption", MetricValue: json.RawMessage(`"210"`), Timestamp: now.Add(-3 * time.Minute).Format(time.RFC3339)},
+			{MetricId: "InletTemp", MetricValue: json.RawMessage(`21`), Timestamp: old.Format(time.RFC3339)},
+			{MetricId: "Status", MetricValue: json.RawMessage(`"OK"`)},
+		},
+	}
+	mc := &Collector{client: &Client{host: "10.0.0.1"}, config: config.Config{}}
+	ch := make(chan string, 10)
+	mc.emitMetricReport(ch, report)
+	close(ch)
+
+	var lines []string
+	for line := range ch {
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("emitMetricReport = %q, want one line per metric", lines)
+	}
+	if !strings.Contains(lines[0], `metric="SystemPowerConsumption"`) || !strings.Contains(lines[0], "} 230 ") {
+		t.Errorf("power line = %q, want the newest value 230 with a timestamp", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "} 21") {
+		t.Errorf("inlet line = %q, want the stale value without a timestamp", lines[1])
+	}
+}
+
+func TestStoredMetricReportsExpire(t *testing.T) {
+	storeMetricReport("10.0.0.9", &MetricReport{Id: "ThermalSensor"})
+	if got := storedMetricReports("10.0.0.9", time.Minute); len(got) != 1 {
+		t.Fatalf("storedMetricReports = %d reports, want 1", len(got))
+	}
+
+	metricReportsMu.Lock()
+	metricReports["10.0.0.9"]["ThermalSensor"].receivedAt = time.Now().Add(-time.Hour)
+	metricReportsMu.Unlock()
+	if got := storedMetricReports("10.0.0.9", time.Minute); len(got) != 0 {
+		t.Errorf("storedMetricReports returned %d expired reports", len(got))
+	}
+
+	storeMetricReport("10.0.0.9", &MetricReport{Id: "ThermalSensor"})
+	dropMetricReports("10.0.0.9")
+	if got := storedMetricReports("10.0.0.9", time.Minute); len(got) != 0 {
+		t.Errorf("storedMetricReports returned %d reports of a dropped stream", len(got))
+	}
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// SelEntry is a system event log entry in the shape shared by all vendors
+type SelEntry struct {
+	Id        string
+	Message   string
+	Component string
+	Severity  string
+	Created   time.Time
+}
+
+// VendorProfile isolates the quirks of one bmc vendor
+type VendorProfile interface {
+	Name() string
+	// Detect reports whether the profile handles the bmc
+	Detect(root *V1Response, system *SystemResponse) bool
+	// Endpoints overrides the endpoints found by the standard discovery
+	Endpoints(client *Client, root *V1Response)
+	// SelEntries retrieves the system event log
+	SelEntries(client *Client) ([]SelEntry, error)
+	// Storage returns the storage controllers and the links of all drives behind them
+	Storage(client *Client) ([]StorageController, []Odata, error)
+	NormalizeDrive(drive *Drive)
+	NormalizeMemory(m *Memory)
+	NormalizeThermal(resp *ThermalResponse)
+	NormalizeNetworkInterface(ni *NetworkInterface)
+	NormalizeNetworkPort(port *NetworkPort)
+	NormalizePowerSupply(psu *PowerSupplyUnit)
+	// Health maps the status of a component to the health value exported
+	Health(status Status) string
+}
+
+// vendorProfiles are detected in this order, the first one whose Detect matches handles the bmc,
+// so a profile goes before any profile that would also match its bmcs, e.g. ilo4 before hpe
+var vendorProfiles = []VendorProfile{
+	dellProfile{},
+	fujitsuProfile{},
+	h3cProfile{},
+	ilo4Profile{},
+	hpeProfile{},
+	huaweiProfile{},
+	inspurProfile{},
+	lenovoProfile{},
+	supermicroProfile{},
+}
+
+func detectVendor(root *V1Response, system *SystemResponse) VendorProfile {
+	log.Printf("vender is %q", system.Manufacturer)
+	for _, profile := range vendorProfiles {
+		if profile.Detect(root, system) {
+			return profile
+		}
+	}
+	return genericProfile{}
+}
+
+func manufacturerContains(system *SystemResponse, names ...string) bool {
+	m := strings.ToLower(system.Manufacturer)
+	for _, name := range names {
+		if strings.Contains(m, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// genericProfile follows the standard redfish schema, vendors embed it and override their quirks
+type genericProfile struct{}
+
+func (genericProfile) Name() string {
+	return "generic"
+}
+
+func (genericProfile) Detect(*V1Response, *SystemResponse) bool {
+	return true
+}
+
+func (genericProfile) Endpoints(*Client, *V1Response) {}
+
+func (genericProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries()
+}
+
+func (genericProfile) Storage(client *Client) ([]StorageController, []Odata, error) {
+	return client.standardStorage(client.storagePath)
+}
+
+func (genericProfile) NormalizeDrive(*Drive) {}
+
+func (genericProfile) NormalizeMemory(*Memory) {}
+
+func (genericProfile) NormalizeThermal(*ThermalResponse) {}
+
+func (genericProfile) NormalizeNetworkInterface(*NetworkInterface) {}
+
+func (genericProfile) NormalizeNetworkPort(*NetworkPort) {}
+
+func (genericProfile) NormalizePowerSupply(*PowerSupplyUnit) {}
+
+func (genericProfile) Health(status Status) string {
+	return status.Health
+}
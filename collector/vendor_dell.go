@@ -0,0 +1,18 @@
+package collector
+
+type dellProfile struct {
+	genericProfile
+}
+
+func (dellProfile) Name() string {
+	return "dell"
+}
+
+func (dellProfile) Detect(_ *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "dell")
+}
+
+// SelEntries falls back to the Logs/Sel collection of iDRACs older than the LogServices
+func (dellProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries(redfishRootPath + "/Managers/iDRAC.Embedded.1/Logs/Sel")
+}
@@ -0,0 +1,30 @@
+package collector
+
+import (
+	"strings"
+)
+
+type fujitsuProfile struct {
+	genericProfile
+}
+
+func (fujitsuProfile) Name() string {
+	return "fujitsu"
+}
+
+func (fujitsuProfile) Detect(root *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "fujitsu") || strings.Contains(strings.ToLower(root.Vendor), "fujitsu")
+}
+
+// SelEntries falls back to the iRMC system event log, the manager is named iRMC instead of numbered
+func (fujitsuProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries(redfishRootPath + "/Managers/iRMC/LogServices/SystemEventLog/Entries")
+}
+
+func (fujitsuProfile) NormalizeDrive(drive *Drive) {
+	fillDrive(drive, drive.Oem.TsFujitsu)
+}
+
+func (fujitsuProfile) NormalizePowerSupply(psu *PowerSupplyUnit) {
+	fillPowerSupply(psu, psu.Oem.TsFujitsu)
+}
@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"log"
+	"strconv"
+	"strings"
+)
+
+type h3cProfile struct {
+	genericProfile
+}
+
+func (h3cProfile) Name() string {
+	return "h3c"
+}
+
+func (h3cProfile) Detect(_ *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "h3c")
+}
+
+// SelEntries falls back to the HDM event log, HDM2 moved it from the manager to the system
+func (h3cProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries(redfishRootPath+"/Managers/1/LogServices/SEL/Entries", client.systemPath+"/LogServices/Log1/Entries")
+}
+
+// Storage takes the drives from the chassis when the RAID controllers of HDM list none,
+// drives on the onboard sata controller are only listed there
+func (h3cProfile) Storage(client *Client) ([]StorageController, []Odata, error) {
+	ctlrs, drives, err := client.standardStorage(client.storagePath)
+	if err != nil {
+		log.Printf("磁盘信息采集错误-%s", err.Error())
+	}
+	if len(drives) > 0 {
+		return ctlrs, drives, nil
+	}
+
+	grp := GroupResponse{}
+	chassisErr := client.redfishGet(redfishRootPath+"/Chassis/1/Drives", &grp)
+	if chassisErr != nil {
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, chassisErr
+	}
+	return ctlrs, grp.Members, nil
+}
+
+// NormalizeDrive replaces the bare index HDM uses as drive id with the location name
+func (h3cProfile) NormalizeDrive(drive *Drive) {
+	if _, err := strconv.Atoi(drive.Id); err == nil && drive.Name != "" {
+		drive.Id = strings.ReplaceAll(drive.Name, " ", "")
+	}
+}
+
+func (h3cProfile) NormalizeMemory(m *Memory) {
+	if _, err := strconv.Atoi(m.Id); err == nil && m.DeviceLocator != "" {
+		m.Id = strings.ReplaceAll(m.DeviceLocator, " ", "")
+	}
+	m.Manufacturer = strings.TrimSpace(m.Manufacturer)
+}
+
+// NormalizeNetworkInterface links the ports of the adapter, HDM leaves the interface without
+// a port link and without a state
+func (h3cProfile) NormalizeNetworkInterface(ni *NetworkInterface) {
+	if ni.Status.State == "" {
+		ni.Status.State = StateEnabled
+	}
+	if ni.GetPorts() == "" && ni.Links.NetworkAdapter.OdataId != "" {
+		ni.NetworkPorts.OdataId = strings.TrimSuffix(ni.Links.NetworkAdapter.OdataId, "/") + "/NetworkPorts"
+	}
+}
+
+// Health reports present HDM components without a health as OK
+func (h3cProfile) Health(status Status) string {
+	if status.Health == "" && status.State == StateEnabled {
+		return "OK"
+	}
+	return status.Health
+}
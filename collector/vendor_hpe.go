@@ -0,0 +1,72 @@
+package collector
+
+import (
+	"log"
+	"strings"
+)
+
+type hpeProfile struct {
+	genericProfile
+}
+
+func (hpeProfile) Name() string {
+	return "hpe"
+}
+
+func (hpeProfile) Detect(_ *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "hpe")
+}
+
+// ilo4Profile handles the pre-redfish 1.0 HP RESTful api of iLO 4
+type ilo4Profile struct {
+	hpeProfile
+}
+
+func (ilo4Profile) Name() string {
+	return "hpe-ilo4"
+}
+
+func (ilo4Profile) Detect(root *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "hpe") && strings.Contains(root.Name, "HP RESTful")
+}
+
+func (ilo4Profile) Endpoints(client *Client, _ *V1Response) {
+	client.memoryPath = "/redfish/v1/Systems/1/Memory/"
+	client.storagePath = "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/"
+}
+
+func (ilo4Profile) Storage(client *Client) ([]StorageController, []Odata, error) {
+	ctlrs, _, err := client.standardStorage(client.storagePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var drives []Odata
+	for i, ctlr := range ctlrs {
+		grp := GroupResponse{}
+		err = client.redfishGet(ctlr.OdataId+"DiskDrives/", &grp)
+		if err != nil {
+			log.Printf("磁盘信息采集错误-%s", err.Error())
+		}
+		ctlrs[i].Drives = grp.Members
+		if len(ctlr.StorageControllers) == 0 {
+			ctlrs[i].StorageControllers = []StorageControllerUnit{ctlr.smartArrayController()}
+		}
+		drives = append(drives, grp.Members...)
+	}
+	return ctlrs, drives, nil
+}
+
+func (ilo4Profile) NormalizeDrive(drive *Drive) {
+	drive.CapacityBytes = 1024 * 1024 * drive.CapacityMiB
+	drive.Protocol = drive.InterfaceType
+	drive.PredictedLifeLeft = 100 - drive.SSDEnduranceUtilizationPercentage
+}
+
+func (ilo4Profile) NormalizeMemory(m *Memory) {
+	m.Manufacturer = strings.TrimSpace(m.Manufacturer)
+	m.RankCount = m.Rank
+	m.MemoryDeviceType = m.DIMMType
+	m.Status.Health = m.DIMMStatus
+	m.CapacityMiB = m.SizeMB
+}
@@ -0,0 +1,246 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+type huaweiProfile struct {
+	genericProfile
+}
+
+func (huaweiProfile) Name() string {
+	return "huawei"
+}
+
+func (huaweiProfile) Detect(_ *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "huawei")
+}
+
+// SelEntries prefers the standard Entries of the iBMC log service and falls back to
+// paging through the oem sel query of older firmware
+func (huaweiProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	entries, err := client.logEntries(client.systemPath + "/LogServices/Log1/Entries")
+	if err == nil && len(entries) > 0 {
+		return entries, nil
+	}
+
+	// the oem query pages from the oldest entry, find the last page first so the newest
+	// entries are the ones read when the log is longer than maxLogPages pages
+	read := func(page int) ([]SelLogEntry, error) {
+		var resp LogRes
+		err := client.redfishGetHuaweiLog(1+page*huaweiSelPageSize, huaweiSelPageSize, &resp)
+		if err != nil || len(resp.Error.ExtendedInfo) == 0 {
+			return nil, err
+		}
+		return resp.Error.ExtendedInfo[0].Oem.Huawei.SelLogEntries, nil
+	}
+	last, pages, err := huaweiSelLastPage(read)
+	if err != nil {
+		return nil, err
+	}
+	first := last - maxLogPages + 1
+	if first > 0 {
+		log.Printf("sel of %s has more than %d entries, only the newest are read", client.host, maxLogPages*huaweiSelPageSize)
+	} else {
+		first = 0
+	}
+
+	entries = nil
+	for page := first; page <= last; page++ {
+		selEntries, ok := pages[page]
+		if !ok {
+			// a partial log would make the sel tracker count the unread entries again later
+			selEntries, err = read(page)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, e := range selEntries {
+			if e.AlertTime == "" {
+				continue
+			}
+			entries = append(entries, e.selEntry())
+		}
+	}
+	return entries, nil
+}
+
+// huaweiSelLastPage finds the last page of the oem sel query, doubling the page until one is
+// not full and then bisecting, the pages read on the way are returned so they aren't read twice.
+// last is -1 when the log is empty
+func huaweiSelLastPage(read func(page int) ([]SelLogEntry, error)) (last int, pages map[int][]SelLogEntry, err error) {
+	pages = map[int][]SelLogEntry{}
+	full := func(page int) (bool, error) {
+		entries, err := read(page)
+		if err != nil {
+			return false, err
+		}
+		pages[page] = entries
+		return len(entries) >= huaweiSelPageSize, nil
+	}
+
+	ok, err := full(0)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !ok {
+		if len(pages[0]) == 0 {
+			return -1, pages, nil
+		}
+		return 0, pages, nil
+	}
+	lo, hi := 0, 1
+	for {
+		// a bmc ignoring StartEntryId returns full pages forever
+		if hi > huaweiSelMaxPage {
+			return lo, pages, nil
+		}
+		ok, err = full(hi)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !ok {
+			break
+		}
+		lo, hi = hi, hi*2
+	}
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		ok, err = full(mid)
+		if err != nil {
+			return 0, nil, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if len(pages[hi]) == 0 {
+		return lo, pages, nil
+	}
+	return hi, pages, nil
+}
+
+func (e SelLogEntry) selEntry() SelEntry {
+	t, _ := time.Parse("2006-01-02 15:04:05", e.AlertTime)
+	switch {
+	case e.Level == "0":
+		e.Level = "normal"
+	case e.Level == "1":
+		e.Level = "warning"
+	case e.Level == "2":
+		e.Level = "error"
+	case e.Level == "3":
+		e.Level = "critical"
+	default:
+		e.Level = "unknown"
+	}
+	component := e.SubjectType
+	if component == "" {
+		component = "Unknown"
+	}
+	return SelEntry{Id: e.EventID, Message: e.EventDesc, Component: component, Severity: e.Level, Created: t}
+}
+
+// NormalizeNetworkPort reports enabled ports as healthy, iBMC derives the port health from the link
+// so an unplugged but enabled port would otherwise show up as failed
+func (huaweiProfile) NormalizeNetworkPort(port *NetworkPort) {
+	if port.Status.State == StateEnabled {
+		port.Status.Health = "OK"
+	}
+}
+
+type LogRes struct {
+	Error struct {
+		Code         string         `json:"code"`
+		Message      string         `json:"message"`
+		ExtendedInfo []ExtendedInfo `json:"@Message.ExtendedInfo"`
+	} `json:"error"`
+}
+
+type ExtendedInfo struct {
+	Oem Oem `json:"Oem"`
+}
+
+type Oem struct {
+	Huawei Huawei `json:"Huawei"`
+}
+
+type Huawei struct {
+	SelLogEntries []SelLogEntry `json:"SelLogEntries"`
+}
+
+type SelLogEntry struct {
+	Level        string          `json:"level,omitempty"`
+	EventID      string          `json:"eventid,omitempty"`
+	SubjectType  string          `json:"subjecttype,omitempty"`
+	EventDesc    string          `json:"eventdesc,omitempty"`
+	TrigMode     string          `json:"trigmode,omitempty"`
+	AlertTime    string          `json:"alerttime,omitempty"`
+	Status       string          `json:"status,omitempty"`
+	EventCode    string          `json:"eventcode,omitempty"`
+	OldEventCode string          `json:"oldeventcode,omitempty"`
+	EventSubject string          `json:"eventsubject,omitempty"`
+	EventSugg    string          `json:"eventsugg,omitempty"`
+	Number       json.RawMessage `json:"number,omitempty"`
+}
+
+// huaweiSelPageSize is the most entries iBMC returns for one sel query
+const huaweiSelPageSize = 50
+
+// huaweiSelMaxPage bounds the search for the last page of the sel query
+const huaweiSelMaxPage = 1 << 12
+
+func (client *Client) redfishGetHuaweiLog(start, count int, res *LogRes) error {
+	url := "https://" + client.host + "/redfish/v1/Systems/1/LogServices/Log1/Actions/Oem/Huawei/LogService.QuerySelLogEntries"
+	data := map[string]interface{}{
+		"StartEntryId": start,
+		"EntriesCount": count,
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	// the sel query only reads entries
+	req = idempotent(req)
+
+	req.Header.Add("Accept", "application/json")
+	req.SetBasicAuth(client.username, client.password)
+
+	resp, err := client.do(req)
+
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		log.Printf("Failed to query url %q: %v", url, err)
+		return err
+	}
+
+	if resp.StatusCode != 200 {
+		log.Printf("Query to url %q returned unexpected status code: %d (%s)", url, resp.StatusCode, resp.Status)
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+	re, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(re, &res)
+	if err != nil {
+		log.Printf("Error decoding response from url %v", err)
+		return err
+	}
+
+	return nil
+}
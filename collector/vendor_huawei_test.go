@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// huaweiSel serves a sel of total entries in pages the way the oem query does
+func huaweiSel(total int, failPage int, reads *[]int) func(page int) ([]SelLogEntry, error) {
+	return func(page int) ([]SelLogEntry, error) {
+		*reads = append(*reads, page)
+		if page == failPage {
+			return nil, errors.New("503 Service Unavailable")
+		}
+		var entries []SelLogEntry
+		for id := page * huaweiSelPageSize; id < total && id < (page+1)*huaweiSelPageSize; id++ {
+			entries = append(entries, SelLogEntry{EventID: strconv.Itoa(id)})
+		}
+		return entries, nil
+	}
+}
+
+func TestHuaweiSelLastPage(t *testing.T) {
+	tests := []struct {
+		total int
+		last  int
+	}{
+		{0, -1},
+		{10, 0},
+		{huaweiSelPageSize, 0},
+		{huaweiSelPageSize + 1, 1},
+		{7*huaweiSelPageSize + 3, 7},
+		{16 * huaweiSelPageSize, 15},
+		{300 * huaweiSelPageSize, 299},
+	}
+	for _, tt := range tests {
+		var reads []int
+		last, pages, err := huaweiSelLastPage(huaweiSel(tt.total, -1, &reads))
+		if err != nil || last != tt.last {
+			t.Errorf("huaweiSelLastPage(%d entries) = %d, %v, want %d", tt.total, last, err, tt.last)
+			continue
+		}
+		for _, page := range reads {
+			if _, ok := pages[page]; !ok {
+				t.Errorf("huaweiSelLastPage(%d entries) didn't return page %d it read", tt.total, page)
+			}
+		}
+		if len(reads) > 20 {
+			t.Errorf("huaweiSelLastPage(%d entries) read %d pages", tt.total, len(reads))
+		}
+	}
+}
+
+func TestHuaweiSelLastPageError(t *testing.T) {
+	for _, failPage := range []int{0, 1, 4} {
+		var reads []int
+		if _, _, err := huaweiSelLastPage(huaweiSel(10*huaweiSelPageSize, failPage, &reads)); err == nil {
+			t.Errorf("huaweiSelLastPage() with page %d failing returned no error", failPage)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"strings"
+)
+
+type inspurProfile struct {
+	genericProfile
+}
+
+type inspurDriveResponse struct {
+	Members []Odata `json:"Members"`
+}
+
+func (inspurProfile) Name() string {
+	return "inspur"
+}
+
+func (inspurProfile) Detect(_ *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "inspur")
+}
+
+// Endpoints fixes the Storages typo of the Inspur system resource
+func (inspurProfile) Endpoints(client *Client, _ *V1Response) {
+	client.storagePath = strings.ReplaceAll(client.storagePath, "Storages", "Storage")
+}
+
+// Storage reads the controllers from Storages, the drives are only listed under the chassis
+func (inspurProfile) Storage(client *Client) ([]StorageController, []Odata, error) {
+	ctlrs, _, err := client.standardStorage("/redfish/v1/Systems/1/Storages")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grp := inspurDriveResponse{}
+	err = client.redfishGet("/redfish/v1/Chassis/1/Drives", &grp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctlrs, grp.Members, nil
+}
+
+func (inspurProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries(redfishRootPath + "/Managers/1/LogServices/Log/Entries")
+}
@@ -0,0 +1,30 @@
+package collector
+
+import (
+	"strings"
+)
+
+type lenovoProfile struct {
+	genericProfile
+}
+
+func (lenovoProfile) Name() string {
+	return "lenovo"
+}
+
+func (lenovoProfile) Detect(root *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "lenovo") || strings.Contains(strings.ToLower(root.Vendor), "lenovo")
+}
+
+// SelEntries falls back to the XCC platform event log
+func (lenovoProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries(client.systemPath+"/LogServices/PlatformLog/Entries", redfishRootPath+"/Managers/1/LogServices/PlatformLog/Entries")
+}
+
+func (lenovoProfile) NormalizeDrive(drive *Drive) {
+	fillDrive(drive, drive.Oem.Lenovo)
+}
+
+func (lenovoProfile) NormalizePowerSupply(psu *PowerSupplyUnit) {
+	fillPowerSupply(psu, psu.Oem.Lenovo)
+}
@@ -0,0 +1,57 @@
+package collector
+
+// fillDrive copies the oem drive fields into the standard fields the bmc left empty
+func fillDrive(drive *Drive, oem *OemDrive) {
+	if oem == nil {
+		return
+	}
+	if drive.Manufacturer == "" {
+		drive.Manufacturer = oem.Manufacturer
+	}
+	if drive.Model == "" {
+		drive.Model = oem.Model
+	}
+	if drive.Model == "" {
+		drive.Model = oem.ProductName
+	}
+	if drive.SerialNumber == "" {
+		drive.SerialNumber = oem.SerialNumber
+	}
+	if drive.Revision == "" {
+		drive.Revision = oem.FirmwareVersion
+	}
+	if drive.CapacityBytes == 0 {
+		drive.CapacityBytes = oem.CapacityBytes
+	}
+	if drive.Status.Health == "" {
+		drive.Status.Health = oem.DriveStatus
+	}
+}
+
+// fillPowerSupply copies the oem power supply fields into the standard fields the bmc left empty
+func fillPowerSupply(psu *PowerSupplyUnit, oem *OemPowerSupply) {
+	if oem == nil {
+		return
+	}
+	if psu.Manufacturer == "" {
+		psu.Manufacturer = oem.Manufacturer
+	}
+	if psu.Model == "" {
+		psu.Model = oem.Model
+	}
+	if psu.SerialNumber == "" {
+		psu.SerialNumber = oem.SerialNumber
+	}
+	if psu.PartNumber == "" {
+		psu.PartNumber = oem.PartNumber
+	}
+	if psu.SparePartNumber == "" {
+		psu.SparePartNumber = oem.FRUNumber
+	}
+	if psu.FirmwareVersion == "" {
+		psu.FirmwareVersion = oem.FirmwareVersion
+	}
+	if psu.PowerCapacityWatts == 0 {
+		psu.PowerCapacityWatts = oem.PowerCapacity
+	}
+}
@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"log"
+	"strings"
+)
+
+type supermicroProfile struct {
+	genericProfile
+}
+
+func (supermicroProfile) Name() string {
+	return "supermicro"
+}
+
+func (supermicroProfile) Detect(root *V1Response, system *SystemResponse) bool {
+	return manufacturerContains(system, "supermicro") || strings.Contains(strings.ToLower(root.Vendor), "supermicro")
+}
+
+// SelEntries falls back to the Log1 service, X11 keeps it under the system and X12/H12 under the manager
+func (supermicroProfile) SelEntries(client *Client) ([]SelEntry, error) {
+	return client.selEntries(client.systemPath+"/LogServices/Log1/Entries", redfishRootPath+"/Managers/1/LogServices/Log1/Entries")
+}
+
+// Storage falls back to the HA-RAID controller path of the Supermicro OEM storage and to the
+// chassis drive collection when the standard storage collection lists no drives
+func (supermicroProfile) Storage(client *Client) ([]StorageController, []Odata, error) {
+	ctlrs, drives, err := client.standardStorage(client.storagePath)
+	if err == nil && len(drives) > 0 {
+		return ctlrs, drives, nil
+	}
+	if err != nil {
+		log.Printf("磁盘信息采集错误-%s", err.Error())
+	}
+
+	ctlr := StorageController{}
+	oemErr := client.redfishGet(client.systemPath+"/Storage/HA-RAID", &ctlr)
+	if oemErr == nil && len(ctlr.Drives) > 0 {
+		ctlr.OdataId = client.systemPath + "/Storage/HA-RAID"
+		return append(ctlrs, ctlr), ctlr.Drives, nil
+	}
+
+	grp := GroupResponse{}
+	chassisErr := client.redfishGet(redfishRootPath+"/Chassis/1/Drives", &grp)
+	if chassisErr == nil {
+		return ctlrs, grp.Members, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctlrs, drives, nil
+}
+
+// NormalizeThermal drops the sensors of unpopulated headers, which Supermicro reports as
+// enabled with no health and a zero reading, and fills in the fan units older firmware omits
+func (supermicroProfile) NormalizeThermal(resp *ThermalResponse) {
+	temps := resp.Temperatures[:0]
+	for _, t := range resp.Temperatures {
+		if t.Status.Health == "" && t.ReadingCelsius == 0 {
+			continue
+		}
+		temps = append(temps, t)
+	}
+	resp.Temperatures = temps
+
+	fans := resp.Fans[:0]
+	for _, f := range resp.Fans {
+		if reading, _ := f.GetReading(); f.Status.Health == "" && reading == 0 {
+			continue
+		}
+		if f.GetUnits() == "" {
+			f.ReadingUnits = "RPM"
+		}
+		fans = append(fans, f)
+	}
+	resp.Fans = fans
+}
@@ -0,0 +1,158 @@
+package collector
+
+import (
+	"testing"
+)
+
+func TestDetectVendor(t *testing.T) {
+	tests := []struct {
+		manufacturer string
+		rootName     string
+		rootVendor   string
+		want         string
+	}{
+		{"Dell Inc.", "Root Service", "Dell", "dell"},
+		{"HPE", "HP RESTful Root Service", "", "hpe-ilo4"},
+		{"HPE", "Root Service", "HPE", "hpe"},
+		{"HP", "HP RESTful Root Service", "", "generic"},
+		{"Huawei", "Root Service", "", "huawei"},
+		{"Inspur", "Root Service", "", "inspur"},
+		{"Supermicro", "Root Service", "", "supermicro"},
+		{"", "Root Service", "Supermicro", "supermicro"},
+		{"H3C", "Root Service", "", "h3c"},
+		{"Lenovo", "Root Service", "", "lenovo"},
+		{"", "Root Service", "Lenovo", "lenovo"},
+		{"FUJITSU", "Root Service", "", "fujitsu"},
+		{"", "Root Service", "Fujitsu", "fujitsu"},
+		{"Contoso", "Root Service", "", "generic"},
+	}
+	for _, tt := range tests {
+		root := &V1Response{Name: tt.rootName, Vendor: tt.rootVendor}
+		system := &SystemResponse{Manufacturer: tt.manufacturer}
+		if got := detectVendor(root, system).Name(); got != tt.want {
+			t.Errorf("detectVendor(%q, %q, %q) = %q, want %q", tt.manufacturer, tt.rootName, tt.rootVendor, got, tt.want)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	tests := []struct {
+		profile VendorProfile
+		status  Status
+		want    string
+	}{
+		{genericProfile{}, Status{Health: "OK", State: StateEnabled}, "OK"},
+		{genericProfile{}, Status{State: StateEnabled}, ""},
+		{h3cProfile{}, Status{State: StateEnabled}, "OK"},
+		{h3cProfile{}, Status{State: StateAbsent}, ""},
+		{h3cProfile{}, Status{Health: "Critical", State: StateEnabled}, "Critical"},
+		{huaweiProfile{}, Status{State: StateEnabled}, ""},
+		{huaweiProfile{}, Status{Health: "Warning", State: StateEnabled}, "Warning"},
+	}
+	for _, tt := range tests {
+		if got := tt.profile.Health(tt.status); got != tt.want {
+			t.Errorf("%s Health(%+v) = %q, want %q", tt.profile.Name(), tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeNetworkPort(t *testing.T) {
+	tests := []struct {
+		profile VendorProfile
+		status  Status
+		want    string
+	}{
+		{genericProfile{}, Status{Health: "Critical", State: StateEnabled}, "Critical"},
+		{huaweiProfile{}, Status{Health: "Critical", State: StateEnabled}, "OK"},
+		{huaweiProfile{}, Status{Health: "Critical", State: "Disabled"}, "Critical"},
+	}
+	for _, tt := range tests {
+		port := NetworkPort{Status: tt.status}
+		tt.profile.NormalizeNetworkPort(&port)
+		if port.Status.Health != tt.want {
+			t.Errorf("%s NormalizeNetworkPort(%+v) health = %q, want %q", tt.profile.Name(), tt.status, port.Status.Health, tt.want)
+		}
+	}
+}
+
+func TestNormalizeDrive(t *testing.T) {
+	tests := []struct {
+		profile VendorProfile
+		drive   Drive
+		check   func(d Drive) bool
+	}{
+		{h3cProfile{}, Drive{Id: "3", Name: "Front Disk 3"}, func(d Drive) bool { return d.Id == "FrontDisk3" }},
+		{h3cProfile{}, Drive{Id: "Disk.Bay.3", Name: "Front Disk 3"}, func(d Drive) bool { return d.Id == "Disk.Bay.3" }},
+		{ilo4Profile{}, Drive{CapacityMiB: 2, InterfaceType: "SAS", SSDEnduranceUtilizationPercentage: 7}, func(d Drive) bool {
+			return d.CapacityBytes == 2*1024*1024 && d.Protocol == "SAS" && d.PredictedLifeLeft == 93
+		}},
+		{lenovoProfile{}, lenovoDrive(), func(d Drive) bool {
+			return d.Model == "ST4000" && d.Manufacturer == "Seagate" && d.Status.Health == "OK"
+		}},
+	}
+	for _, tt := range tests {
+		d := tt.drive
+		tt.profile.NormalizeDrive(&d)
+		if !tt.check(d) {
+			t.Errorf("%s NormalizeDrive(%+v) = %+v", tt.profile.Name(), tt.drive, d)
+		}
+	}
+}
+
+func lenovoDrive() Drive {
+	d := Drive{Manufacturer: "Seagate"}
+	d.Oem.Lenovo = &OemDrive{Manufacturer: "LENOVO", ProductName: "ST4000", DriveStatus: "OK"}
+	return d
+}
+
+func TestNormalizeMemory(t *testing.T) {
+	m := Memory{Id: "1", DeviceLocator: "CPU1 DIMM A1", Manufacturer: " Samsung "}
+	h3cProfile{}.NormalizeMemory(&m)
+	if m.Id != "CPU1DIMMA1" || m.Manufacturer != "Samsung" {
+		t.Errorf("h3c NormalizeMemory = %+v", m)
+	}
+
+	m = Memory{Manufacturer: "HP ", Rank: 2, DIMMType: "DDR4", DIMMStatus: "GoodInUse", SizeMB: 16384}
+	ilo4Profile{}.NormalizeMemory(&m)
+	if m.Manufacturer != "HP" || m.RankCount != 2 || m.MemoryDeviceType != "DDR4" || m.Status.Health != "GoodInUse" || m.CapacityMiB != 16384 {
+		t.Errorf("ilo4 NormalizeMemory = %+v", m)
+	}
+}
+
+func TestNormalizePowerSupply(t *testing.T) {
+	psu := PowerSupplyUnit{Model: "PSU-750"}
+	psu.Oem.TsFujitsu = &OemPowerSupply{Model: "DPS-800", SerialNumber: "S1", FRUNumber: "F1", PowerCapacity: 800}
+	fujitsuProfile{}.NormalizePowerSupply(&psu)
+	if psu.Model != "PSU-750" || psu.SerialNumber != "S1" || psu.SparePartNumber != "F1" || psu.PowerCapacityWatts != 800 {
+		t.Errorf("fujitsu NormalizePowerSupply = %+v", psu)
+	}
+}
+
+func TestNormalizeThermal(t *testing.T) {
+	resp := ThermalResponse{
+		Temperatures: []Temperature{
+			{Name: "CPU1 Temp", ReadingCelsius: 40, Status: Status{Health: "OK"}},
+			{Name: "Empty Header", Status: Status{State: StateEnabled}},
+		},
+		Fans: []Fan{
+			{Name: "FAN1", Reading: 3000.0, Status: Status{Health: "OK"}},
+			{Name: "FAN2", Status: Status{State: StateEnabled}},
+		},
+	}
+	supermicroProfile{}.NormalizeThermal(&resp)
+	if len(resp.Temperatures) != 1 || resp.Temperatures[0].Name != "CPU1 Temp" {
+		t.Errorf("supermicro NormalizeThermal temperatures = %+v", resp.Temperatures)
+	}
+	if len(resp.Fans) != 1 || resp.Fans[0].GetUnits() != "RPM" {
+		t.Errorf("supermicro NormalizeThermal fans = %+v", resp.Fans)
+	}
+}
+
+func TestNormalizeNetworkInterface(t *testing.T) {
+	ni := NetworkInterface{}
+	ni.Links.NetworkAdapter.OdataId = "/redfish/v1/Chassis/1/NetworkAdapters/mainboardLOM/"
+	h3cProfile{}.NormalizeNetworkInterface(&ni)
+	if ni.Status.State != StateEnabled || ni.GetPorts() != "/redfish/v1/Chassis/1/NetworkAdapters/mainboardLOM/NetworkPorts" {
+		t.Errorf("h3c NormalizeNetworkInterface = %+v", ni)
+	}
+}
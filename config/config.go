@@ -2,19 +2,24 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/ghodss/yaml"
 	"log"
 	"os"
 )
 
 type Metrics struct {
-	System  bool `yaml:"system"`
-	Sensors bool `yaml:"sensors"`
-	Power   bool `yaml:"power"`
-	Sel     bool `yaml:"sel"`
-	Storage bool `yaml:"storage"`
-	Memory  bool `yaml:"memory"`
-	Network bool `yaml:"network"`
+	System     bool `yaml:"system"`
+	Sensors    bool `yaml:"sensors"`
+	Power      bool `yaml:"power"`
+	Sel        bool `yaml:"sel"`
+	Storage    bool `yaml:"storage"`
+	Memory     bool `yaml:"memory"`
+	Network    bool `yaml:"network"`
+	Processors bool `yaml:"processors"`
+	Pcie       bool `yaml:"pcie"`
+	// MetricReports of the TelemetryService
+	Telemetry bool `yaml:"telemetry"`
 }
 
 type Basic struct {
@@ -27,10 +32,126 @@ type Hosts struct {
 	Password string `json:"password"`
 }
 
+type Influx struct {
+	Url    string            `yaml:"url"`
+	Token  string            `yaml:"token"`
+	TagMap map[string]string `yaml:"tagMap"`
+}
+
+type Graphite struct {
+	Address string            `yaml:"address"`
+	Prefix  string            `yaml:"prefix"`
+	TagMap  map[string]string `yaml:"tagMap"`
+}
+
+type Loki struct {
+	Url string `yaml:"url"`
+	// static labels added to every stream
+	Labels map[string]string `yaml:"labels"`
+}
+
+type Syslog struct {
+	// udp or tcp, defaults to udp
+	Network string `yaml:"network"`
+	Address string `yaml:"address"`
+}
+
+type Outputs struct {
+	Influx   Influx   `yaml:"influx"`
+	Graphite Graphite `yaml:"graphite"`
+	// log outputs of the sel entries
+	Loki   Loki   `yaml:"loki"`
+	Syslog Syslog `yaml:"syslog"`
+	// polling interval in seconds, 0 disables polling mode
+	Interval int `yaml:"interval"`
+}
+
+type Cache struct {
+	Enabled bool `yaml:"enabled"`
+	// seconds a result stays fresh, Ttls overrides it per collector
+	Ttl  int            `yaml:"ttl"`
+	Ttls map[string]int `yaml:"ttls"`
+	// fraction of the ttl after which a background refresh starts
+	RefreshAhead float64 `yaml:"refreshAhead"`
+	ServeStale   bool    `yaml:"serveStale"`
+	// seconds a stale result may still be served
+	MaxStale int `yaml:"maxStale"`
+	// seconds to wait for fresh data before falling back to the stale result
+	Timeout int `yaml:"timeout"`
+}
+
+type Concurrency struct {
+	// max in-flight redfish requests per bmc
+	MaxPerHost int `yaml:"maxPerHost"`
+	// max in-flight redfish requests across all bmcs
+	MaxTotal int `yaml:"maxTotal"`
+}
+
+type Breaker struct {
+	// consecutive failures before the breaker opens
+	FailureThreshold int `yaml:"failureThreshold"`
+	// seconds of the first cool-down, doubled on every failed probe up to MaxCooldown. The probe is
+	// lazy, it is sent with the first request after the cool-down, so without scrapes or polls
+	// an open breaker keeps reporting open until traffic comes back
+	Cooldown    int `yaml:"cooldown"`
+	MaxCooldown int `yaml:"maxCooldown"`
+}
+
+type Retry struct {
+	// attempts including the first one, 1 disables retries
+	MaxAttempts int `yaml:"maxAttempts"`
+	// milliseconds of the first backoff, doubled on every attempt up to MaxDelay
+	BaseDelay int `yaml:"baseDelay"`
+	MaxDelay  int `yaml:"maxDelay"`
+	// milliseconds one request may spend on retries, no retry starts after it
+	MaxTotal int `yaml:"maxTotal"`
+}
+
+type Sel struct {
+	// emit the legacy idrac_sel_entry series for every entry
+	Legacy bool `yaml:"legacy"`
+	// newest entries exported as redfish_sel_latest_entry_info, 0 disables the series
+	Latest int `yaml:"latest"`
+	// seconds, entries of the first read after a start newer than this are still forwarded
+	// so nothing logged while the exporter was down is lost, 0 uses 3600 and a negative value forwards none
+	Lookback int `yaml:"lookback"`
+}
+
+type Events struct {
+	// register an EventDestination on every bmc of hosts and receive the pushed events
+	Subscribe bool `yaml:"subscribe"`
+	// address of the https listener receiving the events
+	Listen string `yaml:"listen"`
+	// url the bmcs push the events to, it has to reach the listener
+	Destination string `yaml:"destination"`
+	// certificate of the listener, a self-signed one is generated when empty
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	// seconds between the checks that the subscriptions still exist
+	Renew int `yaml:"renew"`
+	// hold the ServerSentEventUri stream of every bmc of hosts open
+	Sse bool `yaml:"sse"`
+	// seconds without any data after which the stream is considered dead and reconnected
+	SseIdleTimeout int `yaml:"sseIdleTimeout"`
+}
+
+type Telemetry struct {
+	// seconds, pushed reports received longer ago are dropped and older values are exported without their timestamp
+	MaxAge int `yaml:"maxAge"`
+}
+
 type Config struct {
-	Hosts   map[string]Hosts `yaml:"hosts"`
-	Basic   Basic            `yaml:"basic"`
-	Metrics Metrics          `yaml:"metrics"`
+	Hosts       map[string]Hosts `yaml:"hosts"`
+	Basic       Basic            `yaml:"basic"`
+	Metrics     Metrics          `yaml:"metrics"`
+	Outputs     Outputs          `yaml:"outputs"`
+	Cache       Cache            `yaml:"cache"`
+	Concurrency Concurrency      `yaml:"concurrency"`
+	Breaker     Breaker          `yaml:"breaker"`
+	Retry       Retry            `yaml:"retry"`
+	Sel         Sel              `yaml:"sel"`
+	Events      Events           `yaml:"events"`
+	Telemetry   Telemetry        `yaml:"telemetry"`
 }
 
 func Init(path string) Config {
@@ -46,9 +167,20 @@ func Init(path string) Config {
 		log.Fatalf("Error unmarshalling YAML: %v", err)
 		return config
 	}
+	if err = config.Validate(); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
 	return config
 }
 
+// Validate rejects the settings the exporter cannot run with
+func (c Config) Validate() error {
+	if c.Events.Subscribe && c.Events.Destination == "" {
+		return errors.New("events.destination is required when events.subscribe is enabled")
+	}
+	return nil
+}
+
 type Map struct {
 	Ip   string `yaml:"ip"`
 	Name string `yaml:"name"`
@@ -59,12 +191,12 @@ func GetMapOfNameAndIp(path, target string) string {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		log.Println("Error reading file:", err)
-		return mapOfIpAndName[0].Name
+		return ""
 	}
 	err = json.Unmarshal(content, &mapOfIpAndName)
-	if err != nil {
+	if err != nil || len(mapOfIpAndName) == 0 {
 		log.Println("Error unmarshalling YAML:", err)
-		return mapOfIpAndName[0].Name
+		return ""
 	}
 	for _, item := range mapOfIpAndName {
 		if item.Ip == target {
@@ -5,10 +5,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"regexp"
 	"server_exporter/collector"
 	"server_exporter/config"
 	"server_exporter/tools"
+	"sync"
+	"syscall"
+	"time"
 )
 
 var (
@@ -17,6 +22,8 @@ var (
 	Conf       string
 )
 
+var ipRe = regexp.MustCompile("^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$")
+
 func main() {
 	flag.StringVar(&Dict, "dict", "", "the map file of name and ip")
 	flag.StringVar(&Conf, "conf", "", "")
@@ -24,55 +31,191 @@ func main() {
 	flag.Parse()
 
 	client := gin.Default()
-	var data tools.Data
 	conf := config.Init(Conf)
 	if conf.Basic.Port == "" {
 		conf.Basic.Port = "9234"
 	}
+	collector.Init(conf)
+	if conf.Outputs.Interval > 0 {
+		go poll(conf)
+	}
+	if conf.Events.Subscribe {
+		if err := collector.StartEventReceiver(conf); err != nil {
+			log.Fatalf("fail to start event receiver-%s", err)
+		}
+		go subscribe(conf)
+	}
+	if conf.Events.Sse {
+		for target := range conf.Hosts {
+			if ipRe.MatchString(target) {
+				go collector.Stream(authOf(conf, target), config.GetMapOfNameAndIp(Dict, target), conf)
+			}
+		}
+	}
 	client.GET("/metrics", func(c *gin.Context) {
 		target := c.Query("target")
 		if target == "" {
 			c.JSON(http.StatusInternalServerError, gin.H{"msg": "param 'target' is required"})
 			return
 		}
-		isValid, _ := regexp.MatchString("^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$", target)
-		if !isValid {
+		if !ipRe.MatchString(target) {
 			c.JSON(http.StatusInternalServerError, gin.H{"msg": "param 'target' is invalid"})
 			return
 		}
 
-		for ip, auth := range conf.Hosts {
-			if ip == target {
-				data.Dat.Host = target
-				data.Dat.Account = auth.Username
-				data.Dat.Password = auth.Password
-			}
-		}
-
-		deviceName := config.GetMapOfNameAndIp(Dict, target)
-
+		data := authOf(conf, target)
 		if data.Dat.Account == "" || data.Dat.Password == "" {
 			c.JSON(http.StatusBadRequest, gin.H{"msg": "params 'account' or 'password' are empty"})
 			return
 		}
-		ch := make(chan string, 100)
-		go func() {
-			collectorClient := collector.NewCollector(data, deviceName, conf, ch)
-			collectorClient.Collect()
-			close(ch)
-		}()
-		for {
-			msg, ok := <-ch
-			if !ok {
-				log.Println("channel closed")
-				return
-			}
+
+		ch, err := collect(conf, data)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+			return
+		}
+		var lines []string
+		for msg := range ch {
+			lines = append(lines, msg)
 			if Prometheus != "" {
 				tools.RemoteWrite(msg, Prometheus)
 			} else {
 				c.String(http.StatusOK, "%s\n", msg)
 			}
 		}
+		log.Println("channel closed")
+		// 开启轮询时由poll写输出，避免同一个点写两次；后台写，输出不可用时不拖住抓取
+		if conf.Outputs.Interval <= 0 {
+			go export(conf, lines)
+		}
+	})
+	client.GET("/api/v1/hosts/:target/inventory", func(c *gin.Context) {
+		target := c.Param("target")
+		if !ipRe.MatchString(target) {
+			c.JSON(http.StatusBadRequest, gin.H{"msg": "param 'target' is invalid"})
+			return
+		}
+
+		data := authOf(conf, target)
+		if data.Dat.Account == "" || data.Dat.Password == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"msg": "params 'account' or 'password' are empty"})
+			return
+		}
+
+		redfishClient, err := collector.GetClient(data, config.GetMapOfNameAndIp(Dict, target))
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"msg": err.Error()})
+			return
+		}
+		inventory, err := redfishClient.Inventory()
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"msg": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, inventory)
 	})
 	log.Fatal(client.Run(conf.Basic.BindIp + ":" + conf.Basic.Port))
 }
+
+// authOf 根据目标ip从配置中取出账号密码
+func authOf(conf config.Config, target string) tools.Data {
+	var data tools.Data
+	data.Dat.Host = target
+	if auth, ok := conf.Hosts[target]; ok {
+		data.Dat.Account = auth.Username
+		data.Dat.Password = auth.Password
+	}
+	return data
+}
+
+// collect 开始采集目标机器，采集结束后关闭返回的channel
+func collect(conf config.Config, data tools.Data) (chan string, error) {
+	deviceName := config.GetMapOfNameAndIp(Dict, data.Dat.Host)
+	ch := make(chan string, 100)
+	collectorClient, err := collector.NewCollector(data, deviceName, conf, ch)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		collectorClient.Collect()
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// export 把采集结果写到influx和graphite
+func export(conf config.Config, lines []string) {
+	if conf.Outputs.Influx.Url != "" {
+		tools.InfluxWrite(lines, conf.Outputs.Influx.Url, conf.Outputs.Influx.Token, conf.Outputs.Influx.TagMap)
+	}
+	if conf.Outputs.Graphite.Address != "" {
+		tools.GraphiteWrite(lines, conf.Outputs.Graphite.Address, conf.Outputs.Graphite.Prefix, conf.Outputs.Graphite.TagMap)
+	}
+}
+
+// poll 定时轮询配置文件中的所有机器，并把结果写到各个输出
+func poll(conf config.Config) {
+	ticker := time.NewTicker(time.Duration(conf.Outputs.Interval) * time.Second)
+	defer ticker.Stop()
+	// 上一轮还没采集完的机器跳过，避免慢的bmc上堆积采集
+	var inFlight sync.Map
+	for {
+		for target := range conf.Hosts {
+			if !ipRe.MatchString(target) {
+				continue
+			}
+			if _, running := inFlight.LoadOrStore(target, true); running {
+				log.Printf("skip polling %s, the previous poll is still running", target)
+				continue
+			}
+			go func(target string) {
+				defer inFlight.Delete(target)
+				ch, err := collect(conf, authOf(conf, target))
+				if err != nil {
+					log.Printf("fail to poll %s-%s", target, err)
+					return
+				}
+				var lines []string
+				for msg := range ch {
+					lines = append(lines, msg)
+					if Prometheus != "" {
+						tools.RemoteWrite(msg, Prometheus)
+					}
+				}
+				export(conf, lines)
+			}(target)
+		}
+		<-ticker.C
+	}
+}
+
+// subscribe 在所有机器上注册事件订阅并定时检查，退出时删除订阅
+func subscribe(conf config.Config) {
+	renew := conf.Events.Renew
+	if renew <= 0 {
+		renew = 3600
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(time.Duration(renew) * time.Second)
+	defer ticker.Stop()
+	for {
+		for target := range conf.Hosts {
+			if !ipRe.MatchString(target) {
+				continue
+			}
+			go func(target string) {
+				err := collector.Subscribe(authOf(conf, target), config.GetMapOfNameAndIp(Dict, target), conf.Events)
+				if err != nil {
+					log.Printf("fail to subscribe events of %s-%s", target, err)
+				}
+			}(target)
+		}
+		select {
+		case <-ticker.C:
+		case <-sig:
+			collector.Unsubscribe()
+			os.Exit(0)
+		}
+	}
+}
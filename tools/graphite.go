@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var graphiteEscaper = strings.NewReplacer(" ", "_", ";", "_", "~", "_", "!", "_", "^", "_", "=", "_")
+
+// GraphiteLine converts a collected metric line into graphite tagged plaintext
+func GraphiteLine(metrics, prefix string, tagMap map[string]string) (string, bool) {
+	sample, ok := ParseSample(metrics)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	if prefix != "" {
+		b.WriteString(strings.TrimSuffix(prefix, "."))
+		b.WriteString(".")
+	}
+	b.WriteString(sample.Name)
+	for _, l := range sample.MapLabels(tagMap) {
+		b.WriteString(";")
+		b.WriteString(graphiteEscaper.Replace(l.Name))
+		b.WriteString("=")
+		b.WriteString(graphiteEscaper.Replace(l.Value))
+	}
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatFloat(sample.Value, 'f', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatInt(sample.Time.Unix(), 10))
+	return b.String(), true
+}
+
+// GraphiteWrite sends metrics to a graphite plaintext listener over tcp
+func GraphiteWrite(metrics []string, address, prefix string, tagMap map[string]string) bool {
+	var b strings.Builder
+	for _, m := range metrics {
+		line, ok := GraphiteLine(m, prefix, tagMap)
+		if !ok {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if b.Len() == 0 {
+		return true
+	}
+
+	conn, err := net.DialTimeout("tcp", address, 10*time.Second)
+	if err != nil {
+		log.Printf("fail to connect graphite %q-%s", address, err)
+		return false
+	}
+	defer conn.Close()
+	_ = conn.SetWriteDeadline(time.Now().Add(30 * time.Second))
+	if _, err = conn.Write([]byte(b.String())); err != nil {
+		log.Printf("fail to write graphite metrics-%s", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// udp 单个数据包的大小上限
+const influxUdpPayload = 8192
+
+var (
+	influxNameEscaper = strings.NewReplacer(",", "\\,", " ", "\\ ")
+	influxTagEscaper  = strings.NewReplacer(",", "\\,", " ", "\\ ", "=", "\\=")
+)
+
+// InfluxLine converts a collected metric line into influx line protocol
+func InfluxLine(metrics string, tagMap map[string]string) (string, bool) {
+	sample, ok := ParseSample(metrics)
+	if !ok {
+		return "", false
+	}
+	// line protocol has no NaN or Inf, one such value fails the whole batch
+	if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.WriteString(influxNameEscaper.Replace(sample.Name))
+	for _, l := range sample.MapLabels(tagMap) {
+		b.WriteString(",")
+		b.WriteString(influxTagEscaper.Replace(l.Name))
+		b.WriteString("=")
+		b.WriteString(influxTagEscaper.Replace(l.Value))
+	}
+	b.WriteString(" value=")
+	b.WriteString(strconv.FormatFloat(sample.Value, 'f', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatInt(sample.Time.UnixNano(), 10))
+	return b.String(), true
+}
+
+// InfluxWrite sends metrics to an influxdb http write url, or to a udp listener when the url is udp://host:port
+func InfluxWrite(metrics []string, server, token string, tagMap map[string]string) bool {
+	lines := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		line, ok := InfluxLine(m, tagMap)
+		if !ok {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return true
+	}
+
+	u, err := url.Parse(server)
+	if err != nil {
+		log.Printf("invalid influx url %q: %v", server, err)
+		return false
+	}
+	if u.Scheme == "udp" {
+		err = influxWriteUdp(lines, u.Host)
+	} else {
+		err = influxWriteHttp(lines, server, token)
+	}
+	if err != nil {
+		log.Printf("fail to write influx metrics-%s", err)
+		return false
+	}
+	return true
+}
+
+func influxWriteHttp(lines []string, server, token string) error {
+	req, err := http.NewRequest("POST", server, strings.NewReader(strings.Join(lines, "\n")+"\n"))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+	return nil
+}
+
+func influxWriteUdp(lines []string, address string) error {
+	conn, err := net.DialTimeout("udp", address, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		if buf.Len() > 0 && buf.Len()+len(line)+1 > influxUdpPayload {
+			if _, err = conn.Write(buf.Bytes()); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	if buf.Len() > 0 {
+		_, err = conn.Write(buf.Bytes())
+	}
+	return err
+}
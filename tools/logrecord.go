@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"time"
+)
+
+// LogRecord is a log line forwarded to the log outputs, such as a sel entry or a bmc event
+type LogRecord struct {
+	Source     string
+	Host       string
+	DeviceName string
+	Severity   string
+	Component  string
+	Id         string
+	Message    string
+	Time       time.Time
+}
+
+func (r LogRecord) timestamp() time.Time {
+	if r.Time.IsZero() {
+		return time.Now()
+	}
+	return r.Time
+}
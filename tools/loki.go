@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// LokiPush sends log records to a loki push url, records with the same labels share a stream
+func LokiPush(records []LogRecord, server string, labels map[string]string) bool {
+	if len(records) == 0 {
+		return true
+	}
+
+	streams := map[string]*lokiStream{}
+	var keys []string
+	for _, r := range records {
+		stream := map[string]string{
+			"source":    r.Source,
+			"host":      r.Host,
+			"device":    r.DeviceName,
+			"severity":  r.Severity,
+			"component": r.Component,
+		}
+		for k, v := range labels {
+			stream[k] = v
+		}
+		for k, v := range stream {
+			if v == "" {
+				delete(stream, k)
+			}
+		}
+		key := lokiStreamKey(stream)
+		s, ok := streams[key]
+		if !ok {
+			s = &lokiStream{Stream: stream}
+			streams[key] = s
+			keys = append(keys, key)
+		}
+		s.Values = append(s.Values, [2]string{strconv.FormatInt(r.timestamp().UnixNano(), 10), r.Message})
+	}
+
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, key := range keys {
+		payload.Streams = append(payload.Streams, streams[key])
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("fail to encode loki streams-%s", err)
+		return false
+	}
+
+	if err = lokiPushHttp(body, server); err != nil {
+		log.Printf("fail to push loki logs-%s", err)
+		return false
+	}
+	return true
+}
+
+func lokiStreamKey(stream map[string]string) string {
+	names := make([]string, 0, len(stream))
+	for k := range stream {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(stream[k])
+		b.WriteString(",")
+	}
+	return b.String()
+}
+
+func lokiPushHttp(body []byte, server string) error {
+	req, err := http.NewRequest("POST", server, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+	return nil
+}
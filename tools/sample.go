@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a single collected metric line broken into its parts
+type Sample struct {
+	Name   string
+	Labels []Label
+	Value  float64
+	Time   time.Time
+}
+
+var (
+	sampleRe = regexp.MustCompile(`^(\w+)\{(.*)\}\s+([-+]?[\d.eE+-]+|NaN)(?:\s+(\d+))?$`)
+	labelRe  = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// ParseSample 把采集到的指标行解析成指标名、标签和值
+func ParseSample(metrics string) (Sample, bool) {
+	matches := sampleRe.FindStringSubmatch(strings.TrimSpace(metrics))
+	if len(matches) < 4 {
+		return Sample{}, false
+	}
+	val, err := strconv.ParseFloat(matches[3], 64)
+	if err != nil {
+		return Sample{}, false
+	}
+	sample := Sample{Name: matches[1], Value: val, Time: time.Now()}
+	if matches[4] != "" {
+		ms, _ := strconv.ParseInt(matches[4], 10, 64)
+		sample.Time = time.UnixMilli(ms)
+	}
+	for _, lm := range labelRe.FindAllStringSubmatch(matches[2], -1) {
+		if len(lm) == 3 && lm[2] != "" {
+			sample.Labels = append(sample.Labels, Label{Name: lm[1], Value: lm[2]})
+		}
+	}
+	return sample, true
+}
+
+// MapLabels renames labels according to tagMap, a label mapped to "" is dropped
+func (s Sample) MapLabels(tagMap map[string]string) []Label {
+	labels := make([]Label, 0, len(s.Labels))
+	for _, l := range s.Labels {
+		name := l.Name
+		if mapped, ok := tagMap[l.Name]; ok {
+			if mapped == "" {
+				continue
+			}
+			name = mapped
+		}
+		labels = append(labels, Label{Name: name, Value: l.Value})
+	}
+	return labels
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSample(t *testing.T) {
+	tests := []struct {
+		line   string
+		ok     bool
+		name   string
+		value  float64
+		labels int
+		ms     int64
+	}{
+		{`idrac_power_supply_health{ip="10.0.0.1", id="0", status="OK"} 0`, true, "idrac_power_supply_health", 0, 3, 0},
+		{`redfish_telemetry_metric_value{ip="10.0.0.1", report="r"} 231.5 1714557540000`, true, "redfish_telemetry_metric_value", 231.5, 2, 1714557540000},
+		{`idrac_sensors_temperature{ip="10.0.0.1", name=""} -7`, true, "idrac_sensors_temperature", -7, 1, 0},
+		{`redfish_sensors_voltage_volts{ip="10.0.0.1"} NaN`, true, "redfish_sensors_voltage_volts", 0, 1, 0},
+		{`no_labels 1`, false, "", 0, 0, 0},
+		{`broken{ip="10.0.0.1"} abc`, false, "", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		sample, ok := ParseSample(tt.line)
+		if ok != tt.ok {
+			t.Errorf("ParseSample(%q) ok = %v, want %v", tt.line, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if sample.Name != tt.name {
+			t.Errorf("ParseSample(%q) name = %q, want %q", tt.line, sample.Name, tt.name)
+		}
+		if tt.value != 0 && sample.Value != tt.value {
+			t.Errorf("ParseSample(%q) value = %v, want %v", tt.line, sample.Value, tt.value)
+		}
+		if len(sample.Labels) != tt.labels {
+			t.Errorf("ParseSample(%q) labels = %v, want %d", tt.line, sample.Labels, tt.labels)
+		}
+		if tt.ms != 0 && !sample.Time.Equal(time.UnixMilli(tt.ms)) {
+			t.Errorf("ParseSample(%q) time = %v, want %v", tt.line, sample.Time, time.UnixMilli(tt.ms))
+		}
+	}
+}
+
+func TestInfluxLineDropsNaN(t *testing.T) {
+	if _, ok := InfluxLine(`redfish_sensors_voltage_volts{ip="10.0.0.1"} NaN`, nil); ok {
+		t.Error("InfluxLine accepted a NaN sample")
+	}
+	line, ok := InfluxLine(`idrac_power_supply_health{ip="10.0.0.1", id="PS 1"} 0 1714557540000`, map[string]string{"ip": "host"})
+	if !ok {
+		t.Fatal("InfluxLine rejected a valid sample")
+	}
+	want := `idrac_power_supply_health,host=10.0.0.1,id=PS\ 1 value=0 1714557540000000000`
+	if line != want {
+		t.Errorf("InfluxLine = %q, want %q", line, want)
+	}
+}
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	syslogFacility = 16 // local0
+	syslogAppName  = "server_exporter"
+	// syslogSdId is the structured data id of the forwarded fields, 32473 is the enterprise number reserved for examples
+	syslogSdId = "redfish@32473"
+)
+
+var syslogSdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// syslogSeverity maps the severities of the bmcs onto the syslog severity codes
+func syslogSeverity(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical", "fatal":
+		return 2
+	case "error":
+		return 3
+	case "warning":
+		return 4
+	case "ok", "normal", "informational", "info":
+		return 6
+	}
+	return 5
+}
+
+// SyslogLine formats a log record as a rfc 5424 message
+func SyslogLine(r LogRecord) string {
+	// HOSTNAME only allows printable ascii, the device name may not be, it goes into the structured data
+	hostname := r.Host
+	if hostname == "" {
+		hostname = "-"
+	}
+	msgId := strings.ToUpper(r.Source)
+	if msgId == "" {
+		msgId = "-"
+	}
+
+	var b strings.Builder
+	b.WriteString("<")
+	b.WriteString(strconv.Itoa(syslogFacility*8 + syslogSeverity(r.Severity)))
+	b.WriteString(">1 ")
+	b.WriteString(r.timestamp().Format(time.RFC3339))
+	b.WriteString(" ")
+	b.WriteString(hostname)
+	b.WriteString(" " + syslogAppName + " ")
+	b.WriteString(strconv.Itoa(os.Getpid()))
+	b.WriteString(" ")
+	b.WriteString(msgId)
+	b.WriteString(" [" + syslogSdId)
+	for _, p := range [][2]string{{"host", r.Host}, {"device", r.DeviceName}, {"severity", r.Severity}, {"component", r.Component}, {"id", r.Id}} {
+		b.WriteString(" ")
+		b.WriteString(p[0])
+		b.WriteString(`="`)
+		b.WriteString(syslogSdEscaper.Replace(p[1]))
+		b.WriteString(`"`)
+	}
+	b.WriteString("] ")
+	b.WriteString(strings.ReplaceAll(r.Message, "\n", " "))
+	return b.String()
+}
+
+// SyslogWrite sends log records to a syslog receiver, tcp messages are framed by octet counting
+func SyslogWrite(records []LogRecord, network, address string) bool {
+	if len(records) == 0 {
+		return true
+	}
+	if network == "" {
+		network = "udp"
+	}
+
+	conn, err := net.DialTimeout(network, address, 10*time.Second)
+	if err != nil {
+		log.Printf("fail to connect syslog %q-%s", address, err)
+		return false
+	}
+	defer conn.Close()
+	_ = conn.SetWriteDeadline(time.Now().Add(30 * time.Second))
+
+	for _, r := range records {
+		line := SyslogLine(r)
+		if network == "tcp" {
+			line = strconv.Itoa(len(line)) + " " + line
+		}
+		if _, err = conn.Write([]byte(line)); err != nil {
+			log.Printf("fail to write syslog messages-%s", err)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogLine(t *testing.T) {
+	r := LogRecord{
+		Source:     "sel",
+		Host:       "10.0.0.1",
+		DeviceName: "机房A 服务器1",
+		Severity:   "critical",
+		Component:  "Power Supply",
+		Id:         "1",
+		Message:    "Power supply redundancy is lost.\nCheck PSU 2",
+		Time:       time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+	}
+	line := SyslogLine(r)
+
+	fields := strings.SplitN(line, " ", 7)
+	if fields[0] != "<130>1" {
+		t.Errorf("priority = %q, want <130>1", fields[0])
+	}
+	if fields[2] != "10.0.0.1" {
+		t.Errorf("hostname = %q, want the host ip", fields[2])
+	}
+	if !strings.Contains(line, `device="机房A 服务器1"`) {
+		t.Errorf("device name missing from structured data: %s", line)
+	}
+	if strings.Contains(line, "\n") {
+		t.Errorf("message not kept on one line: %q", line)
+	}
+}